@@ -0,0 +1,174 @@
+package xmldatetime
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ISOWeek returns the ISO 8601 year and week number of c in its location.
+func (c CustomTime) ISOWeek() (year, week int) {
+	return c.Time.ISOWeek()
+}
+
+// Weekday returns the day of the week of c in its location.
+func (c CustomTime) Weekday() time.Weekday {
+	return c.Time.Weekday()
+}
+
+// IsWeekday reports whether c falls on day d in its location.
+func (c CustomTime) IsWeekday(d time.Weekday) bool {
+	return c.Time.Weekday() == d
+}
+
+// RequireWeekday parses s like Parse and returns an error unless the value
+// falls on day d in its timezone.
+func RequireWeekday(s string, d time.Weekday) error {
+	t, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	if t.Weekday() != d {
+		return fmt.Errorf("%s is a %s, not a %s", s, t.Weekday(), d)
+	}
+	return nil
+}
+
+// YearDay returns the day of the year of c in its location, 1 to 366.
+func (c CustomTime) YearDay() int {
+	return c.Time.YearDay()
+}
+
+// ParseWithYearDay parses s like Parse and also returns the day of the
+// year of the value in its timezone.
+func ParseWithYearDay(s string) (t time.Time, yday int, err error) {
+	t, err = Parse(s)
+	if err != nil {
+		return not, 0, err
+	}
+	return t, t.YearDay(), nil
+}
+
+// DaysInMonth returns the number of days in the month of c in its
+// location.
+func (c CustomTime) DaysInMonth() int {
+	return daysIn(c.Month(), c.Year())
+}
+
+// StartOfDay returns the first instant of the day of c in its location,
+// keeping the zone form. When midnight is skipped by a daylight saving
+// transition the day starts at the transition, e.g. 01:00.
+func (c CustomTime) StartOfDay() CustomTime {
+	return CustomTime{Time: startOfDay(c.Year(), c.Month(), c.Day(), c.Location()), zone: c.zone}
+}
+
+// EndOfDay returns the last nanosecond of the day of c in its location,
+// keeping the zone form. It is the instant before the next StartOfDay, so
+// days shortened or lengthened by daylight saving are handled.
+func (c CustomTime) EndOfDay() CustomTime {
+	next := startOfDay(c.Year(), c.Month(), c.Day()+1, c.Location())
+	return CustomTime{Time: next.Add(-time.Nanosecond), zone: c.zone}
+}
+
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if t.Day() != time.Date(year, month, day, 12, 0, 0, 0, loc).Day() {
+		// midnight does not exist, time.Date went back to the previous day
+		_, t = t.ZoneBounds()
+	}
+	return t
+}
+
+// SnapToPrecision rounds the fractional second of c half up to digits
+// places (0-9), 0 for whole seconds, keeping its zone. It fits a value to
+// the precision a schema facet allows instead of rejecting it.
+func (c CustomTime) SnapToPrecision(digits int) CustomTime {
+	if digits < 0 {
+		digits = 0
+	}
+	if digits < 9 {
+		c.Time = c.Time.Round(time.Duration(pow10[9-digits]))
+	}
+	return c
+}
+
+// Bucket returns the start, in UTC, of the window of the given length
+// containing the instant of c. Windows are aligned to the Unix epoch, so
+// a 5 minute window maps 13:07:30Z to 13:05:00Z and a 24 hour window
+// starts at midnight UTC whatever the zone of c. Windows that are not a
+// whole number of seconds are only supported within the years 1678 to
+// 2262. A window that is not positive returns the instant unchanged.
+func (c CustomTime) Bucket(window time.Duration) time.Time {
+	if window <= 0 {
+		return c.Time.UTC()
+	}
+	if window%time.Second == 0 {
+		w := int64(window / time.Second)
+		sec := c.Unix()
+		r := sec % w
+		if r < 0 {
+			r += w
+		}
+		return time.Unix(sec-r, 0).UTC()
+	}
+	ns := c.UnixNano()
+	r := ns % int64(window)
+	if r < 0 {
+		r += int64(window)
+	}
+	return time.Unix(0, ns-r).UTC()
+}
+
+// RelativeTo describes the instant of c relative to ref, e.g. "2 hours ago"
+// or "in 3 days". Only the instants matter, not the zones. The difference
+// is truncated to the largest unit it reaches:
+//
+//	under 1 minute    just now
+//	under 1 hour      minutes
+//	under 1 day       hours
+//	under 30 days     days
+//	under 365 days    months of 30 days
+//	otherwise         years of 365 days
+func (c CustomTime) RelativeTo(ref time.Time) string {
+	// whole seconds, as time.Duration saturates past 292 years
+	later, earlier := c.Time, ref
+	past := later.Before(earlier)
+	if past {
+		later, earlier = earlier, later
+	}
+	d := later.Unix() - earlier.Unix()
+	if later.Nanosecond() < earlier.Nanosecond() {
+		d--
+	}
+	const minute, hour, day = 60, 60 * 60, 24 * 60 * 60
+	var n int64
+	var unit string
+	switch {
+	case d < minute:
+		return "just now"
+	case d < hour:
+		n, unit = d/minute, "minute"
+	case d < day:
+		n, unit = d/hour, "hour"
+	case d < 30*day:
+		n, unit = d/day, "day"
+	case d < 365*day:
+		n, unit = d/(30*day), "month"
+	default:
+		n, unit = d/(365*day), "year"
+	}
+	var b []byte
+	if !past {
+		b = append(b, "in "...)
+	}
+	b = strconv.AppendInt(b, n, 10)
+	b = append(b, ' ')
+	b = append(b, unit...)
+	if n != 1 {
+		b = append(b, 's')
+	}
+	if past {
+		b = append(b, " ago"...)
+	}
+	return string(b)
+}
@@ -0,0 +1,239 @@
+package xmldatetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCustomTime_ISOWeek(t *testing.T) {
+	for _, v := range []struct {
+		in         string
+		year, week int
+		weekday    time.Weekday
+	}{
+		{"2017-01-01T12:00:00Z", 2016, 52, time.Sunday},
+		{"2017-01-02T12:00:00Z", 2017, 1, time.Monday},
+		{"2018-12-31T12:00:00Z", 2019, 1, time.Monday},
+		// still Sunday in its own zone, Monday in UTC
+		{"2017-01-01T23:00:00-05:00", 2016, 52, time.Sunday},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if y, w := c.ISOWeek(); y != v.year || w != v.week {
+			t.Errorf("%s: want: %d-W%02d, got: %d-W%02d", v.in, v.year, v.week, y, w)
+		}
+		if d := c.Weekday(); d != v.weekday {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.weekday, d)
+		}
+	}
+}
+
+func TestRequireWeekday(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want bool
+	}{
+		{"2017-08-14T09:00:00Z", true},
+		{"2017-08-16T13:07:00Z", false},
+		{"2017-08-14T00:30:00+02:00", true},
+		{"2017-08-13T23:30:00-02:00", false},
+	} {
+		err := RequireWeekday(v.in, time.Monday)
+		if (err == nil) != v.want {
+			t.Errorf("%s: want Monday: %v, got: %v", v.in, v.want, err)
+		}
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := c.IsWeekday(time.Monday); got != v.want {
+			t.Errorf("%s: IsWeekday: want: %v, got: %v", v.in, v.want, got)
+		}
+	}
+	err := RequireWeekday("2017-08-16T13:07:00Z", time.Monday)
+	if err == nil || err.Error() != "2017-08-16T13:07:00Z is a Wednesday, not a Monday" {
+		t.Errorf("want weekday error, got: %v", err)
+	}
+	if err := RequireWeekday("2017-08-16", time.Monday); err == nil {
+		t.Errorf("want parse error, got nil")
+	}
+}
+
+func TestParseWithYearDay(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want int
+	}{
+		{"2016-03-01T00:00:00Z", 61},
+		{"2017-03-01T00:00:00Z", 60},
+		{"2016-12-31T23:59:59Z", 366},
+		{"2017-01-01T00:30:00+02:00", 1},
+	} {
+		tm, yday, err := ParseWithYearDay(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if yday != v.want {
+			t.Errorf("%s: want: %d, got: %d", v.in, v.want, yday)
+		}
+		if got := (CustomTime{Time: tm}).YearDay(); got != v.want {
+			t.Errorf("%s: YearDay: want: %d, got: %d", v.in, v.want, got)
+		}
+	}
+	if _, yday, err := ParseWithYearDay("2017-02-29T00:00:00Z"); err == nil || yday != 0 {
+		t.Errorf("want error, got: %d, %v", yday, err)
+	}
+}
+
+func TestCustomTime_DaysInMonth(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want int
+	}{
+		{"2016-02-10T00:00:00Z", 29},
+		{"2017-02-10T00:00:00Z", 28},
+		{"2000-02-10T00:00:00Z", 29},
+		{"1900-02-10T00:00:00Z", 28},
+		{"2017-04-30T00:00:00Z", 30},
+		{"2017-11-01T00:00:00Z", 30},
+		{"2017-08-16T13:07:00Z", 31},
+		{"2017-12-31T23:59:59Z", 31},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := c.DaysInMonth(); got != v.want {
+			t.Errorf("%s: want: %d, got: %d", v.in, v.want, got)
+		}
+	}
+}
+
+func TestCustomTime_StartEndOfDay(t *testing.T) {
+	var c CustomTime
+	if err := c.Scan("2017-08-16T13:07:00.5+02:00"); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if got := Format(c.StartOfDay().Time); got != "2017-08-16T00:00:00+02:00" {
+		t.Errorf("want: 2017-08-16T00:00:00+02:00, got: %s", got)
+	}
+	if got := Format(c.EndOfDay().Time); got != "2017-08-16T23:59:59.999999999+02:00" {
+		t.Errorf("want: 2017-08-16T23:59:59.999999999+02:00, got: %s", got)
+	}
+
+	// Sao Paulo skipped midnight when daylight saving started in 2017.
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("no zoneinfo: %s", err)
+	}
+	c = CustomTime{Time: time.Date(2017, time.October, 15, 12, 0, 0, 0, loc)}
+	if got := Format(c.StartOfDay().Time); got != "2017-10-15T01:00:00-02:00" {
+		t.Errorf("want: 2017-10-15T01:00:00-02:00, got: %s", got)
+	}
+	c = CustomTime{Time: time.Date(2017, time.October, 14, 12, 0, 0, 0, loc)}
+	if got := Format(c.EndOfDay().Time); got != "2017-10-14T23:59:59.999999999-03:00" {
+		t.Errorf("want: 2017-10-14T23:59:59.999999999-03:00, got: %s", got)
+	}
+}
+
+func TestCustomTime_SnapToPrecision(t *testing.T) {
+	for _, v := range []struct {
+		in     string
+		digits int
+		want   string
+	}{
+		{"2017-08-16T13:07:00.0923+02:00", 0, "2017-08-16T13:07:00+02:00"},
+		{"2017-08-16T13:07:00.0923+02:00", 2, "2017-08-16T13:07:00.09+02:00"},
+		{"2017-08-16T13:07:00.0923+02:00", 3, "2017-08-16T13:07:00.092+02:00"},
+		{"2017-08-16T13:07:00.0923", 9, "2017-08-16T13:07:00.0923"},
+		{"2017-08-16T23:59:59.5Z", 0, "2017-08-17T00:00:00Z"},
+		{"2017-08-16T13:07:00.0925Z", 3, "2017-08-16T13:07:00.093Z"},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		got, _ := c.SnapToPrecision(v.digits).Value()
+		if got != v.want {
+			t.Errorf("%s %d: want: %s, got: %v", v.in, v.digits, v.want, got)
+		}
+	}
+}
+
+func TestCustomTime_Bucket(t *testing.T) {
+	for _, v := range []struct {
+		in     string
+		window time.Duration
+		want   string
+	}{
+		{"2017-08-16T13:07:30Z", 5 * time.Minute, "2017-08-16T13:05:00Z"},
+		{"2017-08-16T13:05:00Z", 5 * time.Minute, "2017-08-16T13:05:00Z"},
+		{"2017-08-16T13:07:30.5+02:00", 5 * time.Minute, "2017-08-16T11:05:00Z"},
+		{"2017-08-16T13:07:30Z", time.Hour, "2017-08-16T13:00:00Z"},
+		{"2017-08-16T13:07:30+05:30", time.Hour, "2017-08-16T07:00:00Z"},
+		{"2017-08-16T13:07:30Z", 24 * time.Hour, "2017-08-16T00:00:00Z"},
+		{"2017-08-16T01:07:30+02:00", 24 * time.Hour, "2017-08-15T00:00:00Z"},
+		{"2017-08-16T13:07:30Z", 7 * 24 * time.Hour, "2017-08-10T00:00:00Z"},
+		{"1969-12-31T23:59:59Z", time.Hour, "1969-12-31T23:00:00Z"},
+		{"2017-08-16T13:07:30.123456789Z", 250 * time.Millisecond, "2017-08-16T13:07:30Z"},
+		{"2017-08-16T13:07:30.623Z", 250 * time.Millisecond, "2017-08-16T13:07:30.5Z"},
+		{"1969-12-31T23:59:59.9Z", 250 * time.Millisecond, "1969-12-31T23:59:59.75Z"},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		got := c.Bucket(v.window)
+		if s := Format(got); s != v.want {
+			t.Errorf("%s %s: want: %s, got: %s", v.in, v.window, v.want, s)
+		}
+		if got.Location() != time.UTC {
+			t.Errorf("%s %s: want UTC, got: %s", v.in, v.window, got.Location())
+		}
+	}
+}
+
+func TestCustomTime_RelativeTo(t *testing.T) {
+	ref := time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC)
+	for _, v := range []struct {
+		in   string
+		want string
+	}{
+		{"2017-08-16T13:07:00Z", "just now"},
+		{"2017-08-16T13:07:59.9Z", "just now"},
+		{"2017-08-16T13:06:30Z", "just now"},
+		{"2017-08-16T13:08:00Z", "in 1 minute"},
+		{"2017-08-16T12:40:00Z", "27 minutes ago"},
+		{"2017-08-16T15:07:00Z", "in 2 hours"},
+		{"2017-08-16T13:07:00+02:00", "2 hours ago"},
+		{"2017-08-16T13:07:00-02:00", "in 2 hours"},
+		{"2017-08-19T13:07:00Z", "in 3 days"},
+		{"2017-08-15T13:07:01Z", "23 hours ago"},
+		{"2017-07-16T13:07:00Z", "1 month ago"},
+		{"2017-12-16T13:07:00Z", "in 4 months"},
+		{"2018-08-16T13:07:00Z", "in 1 year"},
+		{"2007-08-16T13:07:00Z", "10 years ago"},
+		{"1500-08-16T13:07:00Z", "517 years ago"},
+		{"2400-08-16T13:07:00Z", "in 383 years"},
+		{"-9999-01-01T00:00:00Z", "12024 years ago"},
+		{"9999-12-31T23:59:59Z", "in 7987 years"},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := c.RelativeTo(ref); got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+	}
+}
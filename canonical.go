@@ -0,0 +1,161 @@
+package xmldatetime
+
+import (
+	"strings"
+	"time"
+)
+
+// CanonicalString rewrites the dateTime s into its canonical representation
+// (3.2.7.2) working on the lexical form only: a lowercase 't' or 'z' is
+// accepted, trailing zeros of the fraction are dropped and a timezone is
+// written as 'Z', shifting the fields by the offset. A leap second :60
+// moves to the next minute, as Parse and Normalize do. The fraction digits
+// are kept as written, without rounding to nanoseconds.
+func CanonicalString(s string) (string, error) {
+	s = strings.Map(upperDesignator, s)
+	f, err := parse(s)
+	if err != nil {
+		return "", err
+	}
+	frac := ""
+	if f.digits > 0 {
+		i := strings.IndexByte(s, '.')
+		frac = strings.TrimRight(s[i+1:i+1+f.digits], "0")
+	}
+	if f.offset != 0 || f.hour == 24 || f.second == 60 {
+		t := time.Date(f.year, time.Month(f.month), f.day, f.hour, f.minute, f.second, 0, time.UTC).
+			Add(-time.Duration(f.offset) * time.Second)
+		f.year, f.month, f.day = t.Year(), int(t.Month()), t.Day()
+		f.hour, f.minute, f.second = t.Clock()
+	}
+	b := appendFields(make([]byte, 0, len(s)), &f)
+	if frac != "" {
+		b = append(b, '.')
+		b = append(b, frac...)
+	}
+	if f.zone != ZoneNone {
+		b = append(b, 'Z')
+	}
+	return string(b), nil
+}
+
+// IsCanonical reports whether s is a dateTime already in its canonical
+// representation: no trailing zeros in the fraction, 'Z' as the only
+// timezone, uppercase designators and no leap second :60.
+func IsCanonical(s string) bool {
+	c, err := CanonicalString(s)
+	return err == nil && c == s
+}
+
+// EnsureCanonical returns the canonical representation of s and whether
+// it differs from s, so that callers can skip rewriting canonical values.
+func EnsureCanonical(s string) (string, bool, error) {
+	c, err := CanonicalString(s)
+	if err != nil {
+		return "", false, err
+	}
+	if c == s {
+		return s, false, nil
+	}
+	return c, true, nil
+}
+
+// Canonical returns the canonical representation of t: the instant in UTC
+// with 'Z' and no trailing zeros in the fraction.
+func Canonical(t time.Time) string {
+	return string(AppendCanonical(make([]byte, 0, 32), t))
+}
+
+// AppendCanonical appends the canonical representation of t, as returned
+// by Canonical, to dst.
+func AppendCanonical(dst []byte, t time.Time) []byte {
+	f := fieldsOf(t.UTC())
+	return appendCanonical(dst, &f)
+}
+
+// CanonicalLocal returns t like Canonical but keeps its offset instead of
+// converting to UTC, e.g. 2017-08-16T13:07:00+02:00. A zero offset is
+// written per the zone mode of opts, 'Z' by default.
+func CanonicalLocal(t time.Time, opts ...FormatOption) string {
+	f := fieldsOf(t)
+	return string(appendZone(appendFraction(appendFields(make([]byte, 0, 32), &f), f.nsec), t, opts))
+}
+
+// Normalize validates the dateTime in b and returns its canonical
+// representation, reusing the storage of b. A zoneless value stays
+// zoneless, any other is converted to UTC. Like CanonicalString it moves a
+// leap second :60 to the next minute.
+func Normalize(b []byte) ([]byte, error) {
+	f, err := parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+	if f.offset != 0 || f.hour == 24 || f.second == 60 || f.nsec == 1e9 {
+		zone := f.zone
+		f = fieldsOf(f.time().UTC())
+		if zone == ZoneNone {
+			f.zone = ZoneNone
+		}
+	}
+	return appendCanonical(b[:0], &f), nil
+}
+
+// appendCanonical appends f without trailing zeros in the fraction and
+// with 'Z' unless it is zoneless. f must be in UTC.
+func appendCanonical(dst []byte, f *fields) []byte {
+	dst = appendFraction(appendFields(dst, f), f.nsec)
+	if f.zone != ZoneNone {
+		dst = append(dst, 'Z')
+	}
+	return dst
+}
+
+func upperDesignator(r rune) rune {
+	switch r {
+	case 't':
+		return 'T'
+	case 'z':
+		return 'Z'
+	}
+	return r
+}
+
+// appendFields appends the date and time of f up to the seconds.
+func appendFields(b []byte, f *fields) []byte {
+	b = appendDate(b, f.year, f.month, f.day)
+	b = append(b, 'T')
+	return appendClock(b, f.hour, f.minute, f.second)
+}
+
+// appendDate appends '-'? yyyy '-' mm '-' dd.
+func appendDate(b []byte, year, month, day int) []byte {
+	b = appendYear(b, year)
+	b = append(b, '-')
+	b = appendInt(b, month, 2)
+	b = append(b, '-')
+	return appendInt(b, day, 2)
+}
+
+// appendClock appends hh ':' mm ':' ss.
+func appendClock(b []byte, hour, minute, second int) []byte {
+	b = appendInt(b, hour, 2)
+	b = append(b, ':')
+	b = appendInt(b, minute, 2)
+	b = append(b, ':')
+	return appendInt(b, second, 2)
+}
+
+// appendInt appends the non-negative v zero padded to width digits.
+func appendInt(b []byte, v, width int) []byte {
+	var buf [20]byte
+	i := len(buf)
+	for v >= 10 || width > 1 {
+		i--
+		buf[i] = byte('0' + v%10)
+		v /= 10
+		width--
+	}
+	i--
+	buf[i] = byte('0' + v)
+	return append(b, buf[i:]...)
+}
@@ -0,0 +1,185 @@
+package xmldatetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCanonicalString(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-08-16T13:07:00.500z", "2017-08-16T13:07:00.5Z"},
+		{"2017-08-16T13:07:00+00:00", "2017-08-16T13:07:00Z"},
+		{"2017-08-16T13:07:00-00:00", "2017-08-16T13:07:00Z"},
+		{"2017-08-16t13:07:00.000", "2017-08-16T13:07:00"},
+		{"2017-08-16T13:07:00.09251+02:00", "2017-08-16T11:07:00.09251Z"},
+		{"2017-12-31T23:30:00-01:00", "2018-01-01T00:30:00Z"},
+		{"2017-08-16T24:00:00", "2017-08-17T00:00:00"},
+		{"-0044-03-15T12:00:00.12345678912Z", "-0044-03-15T12:00:00.12345678912Z"},
+		{"2017-08-16T13:07:00Z", "2017-08-16T13:07:00Z"},
+		{"2016-12-31T23:59:60Z", "2017-01-01T00:00:00Z"},
+		{"2016-12-31T23:59:60.5", "2017-01-01T00:00:00.5"},
+		{"2017-01-01T01:59:60+02:00", "2017-01-01T00:00:00Z"},
+	} {
+		got, err := CanonicalString(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+	}
+	for _, v := range []string{"", "2017-08-16 13:07:00Z", "2017-08-16T13:07:00+15:00"} {
+		if _, err := CanonicalString(v); err == nil {
+			t.Errorf("want error, got nil: %q", v)
+		}
+	}
+}
+
+func TestIsCanonical(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want bool
+	}{
+		{"2017-08-16T13:07:00.5Z", true},
+		{"2017-08-16T13:07:00Z", true},
+		{"2017-08-16T13:07:00", true},
+		{"2017-08-16T13:07:00.50Z", false},
+		{"2017-08-16T13:07:00.0Z", false},
+		{"2017-08-16T13:07:00+00:00", false},
+		{"2017-08-16T13:07:00+02:00", false},
+		{"2017-08-16T13:07:00.5z", false},
+		{"2017-08-16T24:00:00Z", false},
+		{"2016-12-31T23:59:60Z", false},
+		{"2017-08-16T13:07", false},
+	} {
+		if got := IsCanonical(v.in); got != v.want {
+			t.Errorf("%s: want: %v, got: %v", v.in, v.want, got)
+		}
+	}
+}
+
+func TestEnsureCanonical(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+		changed  bool
+	}{
+		{"2017-08-16T11:07:00.5Z", "2017-08-16T11:07:00.5Z", false},
+		{"2017-08-16T11:07:00", "2017-08-16T11:07:00", false},
+		{"2017-08-16T13:07:00.50+02:00", "2017-08-16T11:07:00.5Z", true},
+		{"2017-08-16t11:07:00z", "2017-08-16T11:07:00Z", true},
+	} {
+		got, changed, err := EnsureCanonical(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got != v.want || changed != v.changed {
+			t.Errorf("%s: want: %s, %v, got: %s, %v", v.in, v.want, v.changed, got, changed)
+		}
+		if changed == IsCanonical(v.in) {
+			t.Errorf("%s: changed %v disagrees with IsCanonical", v.in, changed)
+		}
+	}
+	if _, changed, err := EnsureCanonical("2017-08-16 11:07:00Z"); err == nil || changed {
+		t.Errorf("want error and no change, got: %v, %v", changed, err)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	for _, v := range []struct {
+		in   time.Time
+		want string
+	}{
+		{time.Date(2017, time.August, 16, 13, 7, 0, 5e8, time.FixedZone("", 2*60*60)), "2017-08-16T11:07:00.5Z"},
+		{time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC), "2017-08-16T13:07:00Z"},
+		{time.Date(-44, time.March, 15, 12, 0, 0, 0, time.UTC), "-0044-03-15T12:00:00Z"},
+	} {
+		if got := Canonical(v.in); got != v.want {
+			t.Errorf("want: %s, got: %s", v.want, got)
+		}
+		if got := string(AppendCanonical(nil, v.in)); got != Canonical(v.in) {
+			t.Errorf("AppendCanonical: want: %s, got: %s", Canonical(v.in), got)
+		}
+	}
+}
+
+func TestCanonicalLocal(t *testing.T) {
+	for _, v := range []struct {
+		in, canonical, local, numeric string
+	}{
+		{"2017-08-16T13:07:00+02:00", "2017-08-16T11:07:00Z", "2017-08-16T13:07:00+02:00", "2017-08-16T13:07:00+02:00"},
+		{"2017-08-16T13:07:00+00:00", "2017-08-16T13:07:00Z", "2017-08-16T13:07:00Z", "2017-08-16T13:07:00+00:00"},
+		{"2017-08-16T13:07:00.500Z", "2017-08-16T13:07:00.5Z", "2017-08-16T13:07:00.5Z", "2017-08-16T13:07:00.5+00:00"},
+		{"2017-08-16T01:07:00.25-05:30", "2017-08-16T06:37:00.25Z", "2017-08-16T01:07:00.25-05:30", "2017-08-16T01:07:00.25-05:30"},
+	} {
+		tm, err := Parse(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := Canonical(tm); got != v.canonical {
+			t.Errorf("Canonical(%s): want: %s, got: %s", v.in, v.canonical, got)
+		}
+		if got := string(AppendCanonical(nil, tm)); got != v.canonical {
+			t.Errorf("AppendCanonical(%s): want: %s, got: %s", v.in, v.canonical, got)
+		}
+		if got := CanonicalLocal(tm); got != v.local {
+			t.Errorf("CanonicalLocal(%s): want: %s, got: %s", v.in, v.local, got)
+		}
+		if got := CanonicalLocal(tm, WithZoneMode(ZoneModeNumeric)); got != v.numeric {
+			t.Errorf("CanonicalLocal(%s) numeric: want: %s, got: %s", v.in, v.numeric, got)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-08-16T13:07:00.50+02:00", "2017-08-16T11:07:00.5Z"},
+		{"2017-08-16T13:07:00.000Z", "2017-08-16T13:07:00Z"},
+		{"2017-08-16T13:07:00+00:00", "2017-08-16T13:07:00Z"},
+		{"2017-08-16T13:07:00.10", "2017-08-16T13:07:00.1"},
+		{"2017-08-16T24:00:00", "2017-08-17T00:00:00"},
+		{"2017-12-31T23:30:00-01:00", "2018-01-01T00:30:00Z"},
+		{"2016-12-31T23:59:60Z", "2017-01-01T00:00:00Z"},
+		{"2016-12-31T23:59:60.5", "2017-01-01T00:00:00.5"},
+		{"2017-01-01T01:59:60+02:00", "2017-01-01T00:00:00Z"},
+	} {
+		b := []byte(v.in)
+		got, err := Normalize(b)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if string(got) != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+		if &got[0] != &b[0] {
+			t.Errorf("%s: want the input buffer reused", v.in)
+		}
+	}
+	// Normalize and CanonicalString agree
+	for _, v := range []string{"2016-12-31T23:59:60Z", "2016-12-31T18:59:60.25-05:00", "2017-08-16T24:00:00", "2017-08-16T13:07:00.100+02:00"} {
+		c, err := CanonicalString(v)
+		n, nerr := Normalize([]byte(v))
+		if err != nil || nerr != nil || c != string(n) {
+			t.Errorf("%s: CanonicalString: %s, %v; Normalize: %s, %v", v, c, err, n, nerr)
+		}
+	}
+	if _, err := Normalize([]byte("2017-08-16")); err == nil {
+		t.Errorf("want error, got nil")
+	}
+}
+
+func BenchmarkAppendCanonical(b *testing.B) {
+	b.ReportAllocs()
+	t := time.Date(2017, time.August, 16, 13, 7, 0, 92510000, time.FixedZone("", 2*60*60))
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = AppendCanonical(buf[:0], t)
+	}
+}
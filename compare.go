@@ -0,0 +1,60 @@
+package xmldatetime
+
+import (
+	"math"
+	"time"
+)
+
+// Equal reports whether a and b are the same instant, whatever their
+// zones. It has no same-zone fast path: time.Time.Equal only compares the
+// seconds and nanoseconds, and checking the zones first costs more than it
+// saves (see BenchmarkEqual and BenchmarkTimeEqual).
+func Equal(a, b CustomTime) bool {
+	return a.Time.Equal(b.Time)
+}
+
+// Compare returns -1, 0 or +1 as instant a is before, equal to or after b.
+func Compare(a, b time.Time) int {
+	return a.Compare(b)
+}
+
+// CompareCustomTime is Compare for CustomTime, suitable for
+// slices.SortFunc.
+func CompareCustomTime(a, b CustomTime) int {
+	return a.Time.Compare(b.Time)
+}
+
+var (
+	minKeyTime = time.Unix(0, math.MinInt64)
+	maxKeyTime = time.Unix(0, math.MaxInt64)
+)
+
+// Key returns the instant of c as nanoseconds since the Unix epoch, so
+// that the same instant in any zone has the same key and keys sort like
+// instants. Outside the years 1678 to 2262, which int64 nanoseconds cannot
+// hold, keys saturate at math.MinInt64 and math.MaxInt64.
+func (c CustomTime) Key() int64 {
+	if c.Time.Before(minKeyTime) {
+		return math.MinInt64
+	}
+	if c.Time.After(maxKeyTime) {
+		return math.MaxInt64
+	}
+	return c.UnixNano()
+}
+
+// Now is the clock used by WithinSkew, replaceable in tests.
+var Now = time.Now
+
+// WithinSkew reports whether t lies no more than maxPast before and no
+// more than maxFuture after Now, bounds included.
+func WithinSkew(t time.Time, maxPast, maxFuture time.Duration) bool {
+	now := Now()
+	return !t.Before(now.Add(-maxPast)) && !t.After(now.Add(maxFuture))
+}
+
+// WithinSkew reports whether the instant of c lies within the given skew
+// of Now; see the WithinSkew function.
+func (c CustomTime) WithinSkew(maxPast, maxFuture time.Duration) bool {
+	return WithinSkew(c.Time, maxPast, maxFuture)
+}
@@ -0,0 +1,177 @@
+package xmldatetime
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	mustParse := func(s string) CustomTime {
+		f, err := parse(s)
+		if err != nil {
+			t.Fatalf("%s: %s", s, err)
+		}
+		return CustomTime{Time: f.time(), zone: f.zone}
+	}
+	now := time.Now()
+	for _, v := range []struct {
+		a, b CustomTime
+	}{
+		{mustParse("2017-08-16T13:07:00Z"), mustParse("2017-08-16T13:07:00Z")},
+		{mustParse("2017-08-16T13:07:00Z"), mustParse("2017-08-16T13:07:01Z")},
+		{mustParse("2017-08-16T13:07:00+02:00"), mustParse("2017-08-16T11:07:00Z")},
+		{mustParse("2017-08-16T13:07:00+02:00"), mustParse("2017-08-16T13:07:00+02:00")},
+		{mustParse("2017-08-16T13:07:00+02:00"), mustParse("2017-08-16T13:07:00-02:00")},
+		{mustParse("2017-08-16T11:07:00"), mustParse("2017-08-16T11:07:00Z")},
+		{CustomTime{Time: now}, CustomTime{Time: now.Round(0)}},
+		{CustomTime{Time: now}, CustomTime{Time: now.Add(time.Nanosecond)}},
+	} {
+		want := v.a.Time.Equal(v.b.Time)
+		if got := Equal(v.a, v.b); got != want {
+			t.Errorf("Equal(%s, %s): want: %v, got: %v", v.a.Time, v.b.Time, want, got)
+		}
+		if got := Equal(v.b, v.a); got != want {
+			t.Errorf("Equal(%s, %s): want: %v, got: %v", v.b.Time, v.a.Time, want, got)
+		}
+	}
+}
+
+func TestCompareCustomTime(t *testing.T) {
+	var values []CustomTime
+	for _, v := range []string{
+		"2017-08-16T13:07:00+02:00", // 11:07Z
+		"2017-08-16T11:08:00Z",
+		"2017-08-16T06:00:00-05:00", // 11:00Z
+		"2017-08-16T11:07:30",
+	} {
+		var c CustomTime
+		if err := c.Scan(v); err != nil {
+			t.Fatalf("%s: %s", v, err)
+		}
+		values = append(values, c)
+	}
+	slices.SortFunc(values, CompareCustomTime)
+	want := []string{
+		"2017-08-16T06:00:00-05:00",
+		"2017-08-16T13:07:00+02:00",
+		"2017-08-16T11:07:30",
+		"2017-08-16T11:08:00Z",
+	}
+	for i, c := range values {
+		if got, _ := c.Value(); got != want[i] {
+			t.Errorf("%d: want: %s, got: %s", i, want[i], got)
+		}
+	}
+	if Compare(values[0].Time, values[1].Time) != -1 || Compare(values[1].Time, values[0].Time) != 1 {
+		t.Errorf("Compare: want ordered values")
+	}
+}
+
+func TestCustomTime_Key(t *testing.T) {
+	var a, b, c CustomTime
+	for _, v := range []struct {
+		dst *CustomTime
+		in  string
+	}{
+		{&a, "2017-08-16T13:07:00.5+02:00"},
+		{&b, "2017-08-16T11:07:00.5Z"},
+		{&c, "2017-08-16T11:07:00.500000001Z"},
+	} {
+		if err := v.dst.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			t.FailNow()
+		}
+	}
+	if a.Key() != b.Key() {
+		t.Errorf("want equal keys, got: %d, %d", a.Key(), b.Key())
+	}
+	if c.Key() != b.Key()+1 {
+		t.Errorf("want key %d, got: %d", b.Key()+1, c.Key())
+	}
+	for _, v := range []struct {
+		in   time.Time
+		want int64
+	}{
+		{time.Date(1600, time.January, 1, 0, 0, 0, 0, time.UTC), math.MinInt64},
+		{time.Date(3000, time.January, 1, 0, 0, 0, 0, time.UTC), math.MaxInt64},
+		{time.Unix(0, math.MaxInt64), math.MaxInt64},
+		{time.Unix(0, math.MinInt64), math.MinInt64},
+		{time.Unix(0, 0).In(time.FixedZone("", 2*60*60)), 0},
+	} {
+		if got := (CustomTime{Time: v.in}).Key(); got != v.want {
+			t.Errorf("%s: want: %d, got: %d", v.in, v.want, got)
+		}
+	}
+}
+
+func TestWithinSkew(t *testing.T) {
+	now := time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC)
+	defer func(f func() time.Time) { Now = f }(Now)
+	Now = func() time.Time { return now }
+	for _, v := range []struct {
+		in   string
+		want bool
+	}{
+		{"2017-08-16T13:07:00Z", true},
+		{"2017-08-16T15:07:00+02:00", true},
+		{"2017-08-16T12:07:00Z", true},
+		{"2017-08-16T12:06:59.999Z", false},
+		{"2017-08-16T13:12:00Z", true},
+		{"2017-08-16T13:12:00.001Z", false},
+		{"2017-08-16T14:12:00+01:00", true},
+		{"2018-08-16T13:07:00Z", false},
+		{"2016-08-16T13:07:00Z", false},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := c.WithinSkew(time.Hour, 5*time.Minute); got != v.want {
+			t.Errorf("%s: want: %v, got: %v", v.in, v.want, got)
+		}
+		if got := WithinSkew(c.Time, time.Hour, 5*time.Minute); got != v.want {
+			t.Errorf("WithinSkew(%s): want: %v, got: %v", v.in, v.want, got)
+		}
+	}
+}
+
+// equalCases are the operands of BenchmarkEqual and BenchmarkTimeEqual.
+func equalCases() (CustomTime, []struct {
+	name string
+	y    CustomTime
+}) {
+	x := CustomTime{Time: time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC), zone: ZoneUTC}
+	return x, []struct {
+		name string
+		y    CustomTime
+	}{
+		{"same", x},
+		{"different", CustomTime{Time: x.Add(time.Second), zone: ZoneUTC}},
+		{"offset", CustomTime{Time: x.In(time.FixedZone("", 2*60*60)), zone: ZoneOffset}},
+	}
+}
+
+func BenchmarkEqual(b *testing.B) {
+	x, cases := equalCases()
+	for _, v := range cases {
+		b.Run(v.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Equal(x, v.y)
+			}
+		})
+	}
+}
+
+func BenchmarkTimeEqual(b *testing.B) {
+	x, cases := equalCases()
+	for _, v := range cases {
+		b.Run(v.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				x.Time.Equal(v.y.Time)
+			}
+		})
+	}
+}
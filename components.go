@@ -0,0 +1,57 @@
+package xmldatetime
+
+import "time"
+
+// Components are the fields of a dateTime as written, without building a
+// time.Time, so parsing into them does not allocate. Hour is 24 for
+// 24:00:00 and Nanosecond, the fraction rounded half up, can reach 1e9;
+// Time normalizes both.
+type Components struct {
+	Year, Month, Day     int
+	Hour, Minute, Second int
+	Nanosecond           int
+	HasZone              bool
+	OffsetSeconds        int
+}
+
+// ParseComponents parses s like Parse into its components.
+func ParseComponents(s string) (Components, error) {
+	var c Components
+	err := ParseComponentsInto(s, &c)
+	return c, err
+}
+
+// ParseComponentsInto parses s like Parse into c, which can be reused
+// across calls. c is left unchanged on error.
+func ParseComponentsInto(s string, c *Components) error {
+	f, err := parse(s)
+	if err != nil {
+		return err
+	}
+	*c = Components{
+		Year: f.year, Month: f.month, Day: f.day,
+		Hour: f.hour, Minute: f.minute, Second: f.second,
+		Nanosecond:    f.nsec,
+		HasZone:       f.zone != ZoneNone,
+		OffsetSeconds: f.offset,
+	}
+	return nil
+}
+
+// Time returns c as the time.Time Parse would return.
+func (c *Components) Time() time.Time {
+	return time.Date(c.Year, time.Month(c.Month), c.Day, c.Hour, c.Minute, c.Second, c.Nanosecond, fixedZone(c.OffsetSeconds))
+}
+
+// Components returns the fields of c in its location, e.g. for templates.
+// HasZone is false for a zoneless value.
+func (c CustomTime) Components() Components {
+	f := fieldsOf(c.Time)
+	return Components{
+		Year: f.year, Month: f.month, Day: f.day,
+		Hour: f.hour, Minute: f.minute, Second: f.second,
+		Nanosecond:    f.nsec,
+		HasZone:       c.zone != ZoneNone,
+		OffsetSeconds: f.offset,
+	}
+}
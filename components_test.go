@@ -0,0 +1,80 @@
+package xmldatetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseComponents(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want Components
+	}{
+		{"2017-08-16T13:07:00", Components{Year: 2017, Month: 8, Day: 16, Hour: 13, Minute: 7}},
+		{"2017-08-16T13:07:00.5Z", Components{Year: 2017, Month: 8, Day: 16, Hour: 13, Minute: 7, Nanosecond: 5e8, HasZone: true}},
+		{"-0044-03-15T24:00:00-05:30", Components{Year: -44, Month: 3, Day: 15, Hour: 24, HasZone: true, OffsetSeconds: -(5*60 + 30) * 60}},
+	} {
+		c, err := ParseComponents(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if c != v.want {
+			t.Errorf("%s: want: %+v, got: %+v", v.in, v.want, c)
+		}
+		tm, _ := Parse(v.in)
+		if got := c.Time(); !got.Equal(tm) || got.Location() != tm.Location() {
+			t.Errorf("%s: want time: %s, got: %s", v.in, tm, got)
+		}
+	}
+
+	c := Components{Year: 1}
+	if err := ParseComponentsInto("2017-02-30T13:07:00Z", &c); err == nil {
+		t.Errorf("want error, got nil")
+	}
+	if c != (Components{Year: 1}) {
+		t.Errorf("want components unchanged on error, got: %+v", c)
+	}
+	if err := ParseComponentsInto("2017-08-16T13:07:00+02:00", &c); err != nil {
+		t.Errorf("error: %s", err)
+	}
+	if want := time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC); !c.Time().Equal(want) {
+		t.Errorf("want: %s, got: %s", want, c.Time())
+	}
+	if n := testing.AllocsPerRun(100, func() { ParseComponentsInto("2017-08-16T13:07:00.09251+02:00", &c) }); n != 0 {
+		t.Errorf("want 0 allocs, got: %v", n)
+	}
+}
+
+func TestCustomTime_Components(t *testing.T) {
+	var c CustomTime
+	if err := c.Scan("2017-08-16T13:07:05.09251-05:30"); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	got := c.Components()
+	want := Components{Year: 2017, Month: 8, Day: 16, Hour: 13, Minute: 7, Second: 5, Nanosecond: 92510000, HasZone: true, OffsetSeconds: -(5*60 + 30) * 60}
+	if got != want {
+		t.Errorf("want: %+v, got: %+v", want, got)
+	}
+	if got.Time() != c.Time {
+		t.Errorf("want: %s, got: %s", c.Time, got.Time())
+	}
+	if err := c.Scan("2017-08-16T13:07:05"); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if got := c.Components(); got.HasZone || got.OffsetSeconds != 0 || got.Hour != 13 {
+		t.Errorf("zoneless: got: %+v", got)
+	}
+}
+
+func BenchmarkParseComponentsInto(b *testing.B) {
+	b.ReportAllocs()
+	var c Components
+	for i := 0; i < b.N; i++ {
+		if err := ParseComponentsInto("2017-08-16T13:07:00.09251+02:00", &c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,10 +1,9 @@
 package xmldatetime
 
 import (
+	"bytes"
 	"encoding/xml"
 	"errors"
-	"fmt"
-	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -13,18 +12,54 @@ import (
 
 type CustomTime struct {
 	time.Time
+	zone ZoneForm
+	// zoneName is the IANA zone the value was built in, if any; it is
+	// never written out, as a dateTime only carries the offset.
+	zoneName string
+	// format holds the options of WithFormat, nil for the defaults.
+	format *formatConfig
 }
 
-func exactInt(s string, l int) (int, string, error) {
+// ZoneForm is the lexical form of the timezone of a parsed dateTime.
+type ZoneForm uint8
+
+const (
+	// ZoneNone is a value without timezone.
+	ZoneNone ZoneForm = iota + 1
+	// ZoneUTC is the 'Z' designator.
+	ZoneUTC
+	// ZoneOffset is an explicit ±hh:mm offset.
+	ZoneOffset
+)
+
+// exactInt reads exactly l decimal digits; field names the value in the
+// error, e.g. "two-digit month".
+func exactInt(s string, l int, field string) (int, string, error) {
 	if len(s) < l {
-		return 0, s, errors.New("not enough")
+		return 0, s, errors.New("expected " + field)
 	}
-	i, err := strconv.ParseInt(s[:l], 10, 64)
-	return int(i), s[l:], err
+	n := 0
+	for i := 0; i < l; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, s, errors.New("expected " + field)
+		}
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, s[l:], nil
 }
 
 var not time.Time
 
+// maxLength bounds the input of the parsers, so pathological input is
+// rejected before any scanning. It leaves room for a 37 digit fraction,
+// far beyond nanosecond precision.
+const maxLength = 64
+
+var errTooLong = errors.New("dateTime too long")
+
+// ErrFractionDigits is returned for a '.' not followed by a digit.
+var ErrFractionDigits = errors.New("after . indicating fractional seconds there must be digit")
+
 // Parses implements https://www.w3.org/TR/xmlschema-2 # 3.2.7.1 Lexical representation (dateTime)
 // '-'? yyyy '-' mm '-' dd 'T' hh ':' mm ':' ss ('.' s+)? (zzzzzz)?
 // (('+' | '-') hh ':' mm) | 'Z'
@@ -36,106 +71,338 @@ var not time.Time
 //  PASS
 //  ok  	doz.pl/companions/data	6.298s
 func Parse(s string) (time.Time, error) {
-	sign := 1
-	if s[0] == '-' {
-		sign = -1
-		s = s[1:]
-	} else if s[0] == '+' {
-		return not, errors.New("+ before year not allowed")
+	f, err := parse(s)
+	if err != nil {
+		return not, err
+	}
+	return f.time(), nil
+}
+
+// ParseBytes is Parse for a byte slice.
+func ParseBytes(b []byte) (time.Time, error) {
+	f, err := parse(string(b))
+	if err != nil {
+		return not, err
 	}
-	year, s, err := exactInt(s, 4)
+	return f.time(), nil
+}
+
+// ParseIn parses s and returns the same instant in loc. A zoneless value is
+// taken as UTC before the conversion, as is a nil loc.
+func ParseIn(s string, loc *time.Location) (time.Time, error) {
+	t, err := Parse(s)
 	if err != nil {
 		return not, err
 	}
-	year *= sign
-	if s[0] != '-' {
-		return not, errors.New("expected - in dateTime format after 4 digit year")
+	if loc == nil {
+		return t.UTC(), nil
 	}
-	s = s[1:]
+	return t.In(loc), nil
+}
 
-	month, s, err := exactInt(s, 2)
+// ParseDateTimeStamp parses an xs:dateTimeStamp, a dateTime which must have
+// a timezone.
+func ParseDateTimeStamp(s string) (time.Time, error) {
+	f, err := parse(s)
+	if err == nil {
+		err = f.checkTimezone()
+	}
 	if err != nil {
 		return not, err
 	}
-	if s[0] != '-' {
-		return not, errors.New("expected - in dateTime format after 2 digit month")
+	return f.time(), nil
+}
+
+func (f *fields) checkTimezone() error {
+	if f.zone == ZoneNone {
+		return errors.New("timezone required")
+	}
+	return nil
+}
+
+// Validate reports whether s is a valid dateTime. It does the same work
+// as Parse without building the time.Time.
+func Validate(s string) error {
+	_, err := parse(s)
+	return err
+}
+
+// ValidateAll validates values in order and returns the index and error of
+// the first invalid one, or -1 and nil when all are valid.
+func ValidateAll(values []string) (int, error) {
+	for i, v := range values {
+		if err := Validate(v); err != nil {
+			return i, err
+		}
+	}
+	return -1, nil
+}
+
+// ValidateFast is Validate for byte input which only reports validity.
+func ValidateFast(b []byte) bool {
+	// lexField, so an invalid value does not allocate a ParseError
+	_, _, err := defaultDecoder.lexField(string(b))
+	return err == nil
+}
+
+// fields holds the lexical components of a dateTime.
+type fields struct {
+	year, month, day, hour, minute, second, nsec int
+	offset                                       int
+	zone                                         ZoneForm
+	digits                                       int // of the fractional second
+	fracNonzero                                  bool
+}
+
+// time builds the time.Time of f. The location of an offset is only looked
+// up here, so paths which stop at the fields never touch it.
+func (f *fields) time() time.Time {
+	return time.Date(f.year, time.Month(f.month), f.day, f.hour, f.minute, f.second, f.nsec, fixedZone(f.offset))
+}
+
+// fieldsOf returns the fields of t, with its offset as the timezone.
+func fieldsOf(t time.Time) fields {
+	_, offset := t.Zone()
+	f := fields{offset: offset, zone: offsetForm(offset), nsec: t.Nanosecond()}
+	y, m, d := t.Date()
+	f.year, f.month, f.day = y, int(m), d
+	f.hour, f.minute, f.second = t.Clock()
+	return f
+}
+
+func parse(s string) (fields, error) {
+	return defaultDecoder.lex(s)
+}
+
+// lex reads the fields of the dateTime in s. It only applies the Decoder
+// options which change the lexical form.
+func (d *Decoder) lex(s string) (fields, error) {
+	f, field, err := d.lexField(s)
+	if err != nil {
+		return f, d.wrapErr(s, field, err)
+	}
+	return f, nil
+}
+
+// lexField is lex which also names the part of s an error is about.
+func (d *Decoder) lexField(s string) (fields, string, error) {
+	var f fields
+	if len(s) == 0 {
+		return f, "dateTime", errors.New("empty dateTime")
+	}
+	if len(s) > maxLength {
+		return f, "dateTime", errTooLong
+	}
+	// e.g. the NUL padding of a fixed-width buffer
+	if c := s[len(s)-1]; c < ' ' || c == 0x7f {
+		return f, "dateTime", errors.New("unexpected trailing byte " + strconv.QuoteRune(rune(c)))
+	}
+	if s[0] == 'T' || isZone(s) {
+		return f, "date", errors.New("missing date component")
+	}
+	s, err := f.lexDate(s)
+	if err != nil {
+		return f, "date", err
+	}
+	if len(s) == 0 || s[0] != 'T' {
+		return f, "dateTime", errors.New("expected T in dateTime format")
+	}
+	clock := s[1:]
+	s, err = f.lexTime(clock)
+	if err != nil {
+		return f, "time", err
+	}
+	if d.rounding != RoundHalfUp && f.digits > 9 {
+		// the fraction follows hh:mm:ss.
+		f.nsec = roundFraction(clock[9:9+f.digits], d.rounding)
+	}
+	f.offset, f.zone, err = d.parseOffset(s)
+	if err != nil {
+		return f, "timezone", err
+	}
+	if err := f.checkDate(); err != nil {
+		return f, "date", err
+	}
+	if err := f.checkTime(); err != nil {
+		return f, "time", err
+	}
+	return f, "", nil
+}
+
+// lexDate reads '-'? yyyy '-' mm '-' dd and returns the rest of s.
+func (f *fields) lexDate(s string) (string, error) {
+	s, err := f.lexYear(s)
+	if err != nil {
+		return s, err
+	}
+	if len(s) == 0 || s[0] != '-' {
+		return s, errors.New("expected - in dateTime format after 4 digit year")
 	}
 	s = s[1:]
 
-	day, s, err := exactInt(s, 2)
+	f.month, s, err = exactInt(s, 2, "two-digit month")
 	if err != nil {
-		return not, err
+		return s, err
 	}
-	if s[0] != 'T' {
-		return not, errors.New("expected T in dateTime format")
+	if len(s) == 0 || s[0] != '-' {
+		return s, errors.New("expected - in dateTime format after 2 digit month")
 	}
 	s = s[1:]
 
-	hour, s, err := exactInt(s, 2)
+	f.day, s, err = exactInt(s, 2, "two-digit day")
+	return s, err
+}
+
+// lexYear reads '-'? yyyy.
+func (f *fields) lexYear(s string) (string, error) {
+	sign := 1
+	if len(s) > 0 && s[0] == '-' {
+		sign = -1
+		s = s[1:]
+	} else if len(s) > 0 && s[0] == '+' {
+		return s, errors.New("+ before year not allowed")
+	}
+	year, s, err := exactInt(s, 4, "four-digit year")
+	f.year = year * sign
+	return s, err
+}
+
+// lexTime reads hh ':' mm ':' ss ('.' s+)? and returns the rest of s.
+func (f *fields) lexTime(s string) (string, error) {
+	var err error
+	f.hour, s, err = exactInt(s, 2, "two-digit hour")
 	if err != nil {
-		return not, err
+		return s, err
 	}
-	if s[0] != ':' {
-		return not, errors.New("expected : in dateTime format after 2 digit hour")
+	if len(s) == 0 || s[0] != ':' {
+		return s, errors.New("expected : in dateTime format after 2 digit hour")
 	}
 	s = s[1:]
 
-	minute, s, err := exactInt(s, 2)
+	f.minute, s, err = exactInt(s, 2, "two-digit minute")
 	if err != nil {
-		return not, err
+		return s, err
 	}
-	if s[0] != ':' {
-		return not, errors.New("expected : in dateTime format after 2 digit minute")
+	if len(s) == 0 || s[0] != ':' {
+		return s, errors.New("expected : in dateTime format after 2 digit minute")
 	}
 	s = s[1:]
 
-	second, s, err := exactInt(s, 2)
+	f.second, s, err = exactInt(s, 2, "two-digit second")
 	if err != nil {
-		return not, err
+		return s, err
 	}
-	nsec := 0
 	if len(s) > 0 && s[0] == '.' {
-		nsec, s, err = parseFractionalSecond(s[1:])
+		frac := s[1:]
+		f.nsec, s, err = parseFractionalSecond(frac)
 		if err != nil {
-			return not, err
+			return s, err
 		}
+		f.digits = len(frac) - len(s)
+		f.fracNonzero = strings.Trim(frac[:f.digits], "0") != ""
 	}
-	loc, err := parseTz(s)
-	if err != nil {
-		return not, err
+	return s, nil
+}
+
+// isZone reports whether s is only a timezone, 'Z' or ±hh:mm.
+func isZone(s string) bool {
+	return s == "Z" || len(s) == 6 && (s[0] == '+' || s[0] == '-') && s[3] == ':'
+}
+
+// checkDate validates the month and the day of the month.
+func (f *fields) checkDate() error {
+	if f.month < 1 || f.month > 12 {
+		return errors.New("month must be between 01 and 12")
+	}
+	if f.day < 1 || f.day > daysIn(time.Month(f.month), f.year) {
+		return errors.New("day out of range for month")
 	}
+	return nil
+}
 
-	return time.Date(year, time.Month(month), day, hour, minute, second, nsec, loc), nil
+// checkTime validates the clock. Hour 24 is allowed only as 24:00:00, a
+// fraction must be zeros as written, not only after rounding. A leap
+// second 60 is accepted; time.Date moves both to the next minute.
+func (f *fields) checkTime() error {
+	if f.hour > 24 || f.hour == 24 && (f.minute != 0 || f.second != 0 || f.fracNonzero) {
+		return errors.New("hour must be between 00 and 23, or 24:00:00")
+	}
+	if f.minute > 59 {
+		return errors.New("minute must be between 00 and 59")
+	}
+	if f.second > 60 {
+		return errors.New("second must be between 00 and 60")
+	}
+	return nil
 }
 
+func daysIn(m time.Month, year int) int {
+	switch m {
+	case time.February:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+	return 31
+}
+
+// pow10 holds the powers of ten used to scale a fraction to nanoseconds.
+var pow10 = [...]int{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9}
+
+// parseFractionalSecond reads the digits after '.' as nanoseconds. Digits
+// beyond nanosecond precision are rounded half up, which may return 1e9;
+// time.Date carries it into the seconds. Only nine digits are accumulated,
+// the tenth one decides the rounding, so the result fits a 32-bit int.
 func parseFractionalSecond(s string) (int, string, error) {
 	i := 0
-	lastDigit := 0
 	var nsec int
-	for ; i < len(s) && i < 10 && '0' <= s[i] && s[i] <= '9'; i++ {
-		nsec *= 10
-		lastDigit = int(s[i] - '0')
-		nsec = nsec + lastDigit
+	roundUp := false
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+		if i < 9 {
+			nsec = nsec*10 + int(s[i]-'0')
+		} else if i == 9 {
+			roundUp = s[i] >= '5'
+		}
 	}
 	if i == 0 {
-		return nsec, s, errors.New("after . indicating fractional seconds there must be digit")
-	}
-	if lastDigit == 0 {
-		// https://www.w3.org/TR/xmlschema-2/#dateTime
-		// 3.2.7.2 Canonical representation
-		// The fractional second string, if present, must not end in '0';
-		return nsec, s, errors.New("fractional second must not end in '0'")
+		return nsec, s, ErrFractionDigits
 	}
+	// Trailing zeros are only disallowed in the canonical representation
+	// (3.2.7.2), the lexical space accepts them.
 	s = s[i:]
-	if i > 9 {
-		//nsec = nsec / int(math.Pow10(i-9))
-		return nsec, s, errors.New("does not support fraction with precision smaller than 1e-9")
-	} else if i < 9 {
-		nsec = nsec * int(math.Pow10(9-i))
+	if i < 9 {
+		nsec *= pow10[9-i]
+	}
+	if roundUp {
+		nsec++
 	}
 	return nsec, s, nil
 }
 
+// roundFraction returns the nanoseconds of the fraction digits, of more
+// than nine digits, reduced as mode says.
+func roundFraction(digits string, mode RoundingMode) int {
+	var nsec int
+	for i := 0; i < 9; i++ {
+		nsec = nsec*10 + int(digits[i]-'0')
+	}
+	rest := digits[9:]
+	switch mode {
+	case RoundHalfUp:
+		if rest[0] >= '5' {
+			nsec++
+		}
+	case RoundHalfEven:
+		if rest[0] > '5' || rest[0] == '5' && (strings.TrimRight(rest[1:], "0") != "" || nsec%2 == 1) {
+			nsec++
+		}
+	}
+	return nsec
+}
+
 var (
 	xmlDateTimeRe = regexp.MustCompile(
 		`^(?P<year>-?\d{4})-(?P<month>\d{2})-(?P<day>\d{2})T(?P<hour>\d{2}):(?P<min>\d{2}):(?P<sec>\d{2})` +
@@ -143,6 +410,9 @@ var (
 )
 
 func ParseRe(s string) (time.Time, error) {
+	if len(s) > maxLength {
+		return not, errTooLong
+	}
 	sub := xmlDateTimeRe.FindStringSubmatch(s)
 	if len(sub) == 0 {
 		return not, errors.New("does not match format")
@@ -175,24 +445,21 @@ func ParseRe(s string) (time.Time, error) {
 	}
 	consumed++
 	if len(sub) >= 9 && (sub[8] != "" || sub[9] != "") {
-		tmh, err := strconv.ParseInt(sub[8], 10, 32)
-		if err != nil {
-			return not, errors.New("cannot parse zone hour")
-		}
-		if tmh > 14 {
-			return not, errors.New("max timezone hour is 14")
-		}
-		tmm, err := strconv.ParseInt(sub[9], 10, 32)
+		// the ranges of the minute and of the offset are those of Parse
+		offset, _, err := defaultDecoder.parseOffset(sub[8] + ":" + sub[9])
 		if err != nil {
-			return not, errors.New("cannot parse zone minute")
+			return not, err
 		}
-		res.loc = time.FixedZone("", ((int(tmh)*60)+int(tmm))*60)
+		res.loc = time.FixedZone("", offset)
 	}
 	return time.Date(res.year, time.Month(res.month), res.day,
 		res.hour, res.minute, res.second, res.nsecond, res.loc), nil
 }
 
 func ParseRe2(s string) (time.Time, error) {
+	if len(s) > maxLength {
+		return not, errTooLong
+	}
 	sub := xmlDateTimeRe.FindStringSubmatch(s)
 	if len(sub) == 0 {
 		return not, errors.New("does not match format")
@@ -252,33 +519,48 @@ func ParseRe2(s string) (time.Time, error) {
 		res.nsecond = nsec
 	}
 	if len(sub) >= 8 && (sub[8] != "" || sub[9] != "") {
-		tmh, err := strconv.ParseInt(sub[8], 10, 32)
-		if err != nil {
-			return not, errors.New("cannot parse zone hour")
-		}
-		if tmh > 14 {
-			return not, errors.New("max timezone hour is 14")
-		}
-		tmm, err := strconv.ParseInt(sub[9], 10, 32)
+		// the ranges of the minute and of the offset are those of Parse
+		offset, _, err := defaultDecoder.parseOffset(sub[8] + ":" + sub[9])
 		if err != nil {
-			return not, errors.New("cannot parse zone minute")
+			return not, err
 		}
-		res.loc = time.FixedZone("", ((int(tmh)*60)+int(tmm))*60)
+		res.loc = time.FixedZone("", offset)
 	}
 	return time.Date(res.year, time.Month(res.month), res.day,
 		res.hour, res.minute, res.second, res.nsecond, res.loc), nil
 }
 
-func parseTz(s string) (*time.Location, error) {
-	loc := time.UTC
+// parseOffset parses the optional timezone suffix and returns its offset
+// in seconds east of UTC and its form.
+func (d *Decoder) parseOffset(s string) (int, ZoneForm, error) {
 	switch len(s) {
 	case 0:
+		return 0, ZoneNone, nil
 	case 1:
 		if s[0] != 'Z' {
-			return nil, errors.New("tz 1 char but not Z")
+			return 0, 0, errors.New("tz 1 char but not Z")
+		}
+	case 3:
+		if !d.hourOnlyOffset {
+			return 0, 0, errors.New("timezone requires exactly 6 characters if not Z")
+		}
+		sign := 1
+		switch s[0] {
+		case '+':
+		case '-':
+			sign = -1
+		default:
+			return 0, 0, errors.New("timezone must start from + or -")
+		}
+		hz, _, err := exactInt(s[1:], 2, "two-digit timezone hour")
+		if err != nil {
+			return 0, 0, err
 		}
+		if hz > d.maxOffsetHours() {
+			return 0, 0, d.errOffsetRange()
+		}
+		return sign * hz * 60 * 60, ZoneOffset, nil
 	case 6:
-		tz := s
 		sign := 0
 		switch s[0] {
 		case '+':
@@ -286,62 +568,168 @@ func parseTz(s string) (*time.Location, error) {
 		case '-':
 			sign = -1
 		default:
-			return nil, errors.New("timezone must start from + or -")
+			return 0, 0, errors.New("timezone must start from + or -")
 		}
 		s = s[1:]
 
-		hz, s, err := exactInt(s, 2)
+		hz, s, err := exactInt(s, 2, "two-digit timezone hour")
 		if err != nil {
-			return nil, err
-		}
-		if hz > 14 {
-			return nil, errors.New("max timezone hour is 14")
+			return 0, 0, err
 		}
 		if s[0] != ':' {
-			return nil, errors.New("expected : in dateTime format after 2 digit timezone hour")
+			return 0, 0, errors.New("expected : in dateTime format after 2 digit timezone hour")
 		}
 		s = s[1:]
-		mz, s, err := exactInt(s, 2)
+		mz, s, err := exactInt(s, 2, "two-digit timezone minute")
 		if err != nil {
-			return nil, err
+			return 0, 0, err
+		}
+		// minutes are checked first so that e.g. +13:60 is not taken as +14:00
+		if mz > 59 {
+			return 0, 0, errors.New("timezone minute must be between 00 and 59")
 		}
-		loc = time.FixedZone(tz, sign*((hz*60)+mz)*60)
+		if hz == d.maxOffsetHours() && mz != 0 {
+			return 0, 0, d.errOffsetMinutes()
+		}
+		if hz*60+mz > d.maxOffsetHours()*60 {
+			return 0, 0, d.errOffsetRange()
+		}
+		return sign * ((hz * 60) + mz) * 60, ZoneOffset, nil
 	default:
-		return nil, errors.New("timezone requires exactly 6 characters if not Z")
+		return 0, 0, errors.New("timezone requires exactly 6 characters if not Z")
 	}
-	return loc, nil
+	return 0, ZoneUTC, nil
+}
+
+// offsetName returns the ±hh:mm name used for fixed zones.
+func offsetName(offset int) string {
+	return string(appendOffset(nil, offset, ZoneModeNumeric))
 }
 
 func (c *CustomTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	var v string
-	d.DecodeElement(&v, &start)
-	t, err := Parse(v)
+	if isNil(start) {
+		*c = CustomTime{}
+		return d.Skip()
+	}
+	var buf [64]byte
+	v := buf[:0]
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			v = append(v, tok...)
+		case xml.StartElement:
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return c.unmarshal(v)
+		}
+	}
+}
+
+func (c *CustomTime) unmarshal(b []byte) error {
+	b = bytes.Trim(b, " \t\r\n")
+	if len(b) == 0 {
+		// an empty element, e.g. <t/>, holds no value
+		*c = CustomTime{}
+		return nil
+	}
+	f, err := parse(string(b))
 	if err != nil {
 		return err
 	}
-	c.Time = t
+	*c = CustomTime{Time: f.time(), zone: f.zone}
 	return nil
 }
 
+// IsZoneless reports whether c was parsed from a value without timezone.
+func (c CustomTime) IsZoneless() bool {
+	return c.zone == ZoneNone
+}
+
+// OffsetString returns the timezone of c as written in a dateTime: "Z" for
+// UTC, ±hh:mm for an explicit offset and "" for a zoneless value.
+func (c CustomTime) OffsetString() string {
+	_, offset := c.Zone()
+	switch c.zone {
+	case ZoneNone:
+		return ""
+	case ZoneOffset:
+		return string(appendOffset(nil, offset, ZoneModeNumeric))
+	}
+	return string(AppendOffset(nil, offset))
+}
+
+// OffsetMinutes returns the timezone of c in signed minutes east of UTC,
+// and false for a zoneless value.
+func (c CustomTime) OffsetMinutes() (int, bool) {
+	if c.zone == ZoneNone {
+		return 0, false
+	}
+	_, offset := c.Zone()
+	return offset / 60, true
+}
+
 func stringify(t time.Time) string {
-	v := t.Format("2006-01-02T15:04:05")
-	if n := t.Nanosecond(); n > 0 {
-		v += strings.TrimRight(fmt.Sprintf(".%09d", n), "0")
-	}
-	if loc := t.Location(); loc != nil {
-		_, offset := t.Zone()
-		if offset != 0 {
-			minutes := offset / 60
-			hours := minutes / 60
-			if hours > 0 {
-				v += "+"
-			}
-			v += fmt.Sprintf("%03d:%02d", hours, minutes-60*hours)
-		}
-	}
-	return v
+	_, offset := t.Zone()
+	return string(AppendOffset([]byte(stringifyLocal(t)), offset))
+}
+
+// stringifyLocal formats t without the timezone.
+func stringifyLocal(t time.Time) string {
+	return string(appendFraction([]byte(t.Format("2006-01-02T15:04:05")), t.Nanosecond()))
+}
+
+// IsZero reports whether c is unset. Unlike time.Time.IsZero it is false
+// for a parsed 0001-01-01T00:00:00Z, which is a value.
+func (c CustomTime) IsZero() bool {
+	return c.zone == 0 && c.Time.IsZero()
 }
 
 func (c *CustomTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	return e.EncodeElement(stringify(c.Time), start)
+	if c.IsZero() {
+		// the counterpart of UnmarshalXML reading an empty element
+		return e.EncodeElement("", start)
+	}
+	return e.EncodeElement(c.marshalText(), start)
+}
+
+// UnmarshalXMLAttr parses the dateTime of an attribute, collapsing
+// surrounding whitespace as UnmarshalXML does for elements.
+func (c *CustomTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return c.unmarshal([]byte(attr.Value))
+}
+
+// MarshalXMLAttr writes c as an attribute, which is omitted for a zero
+// value.
+func (c *CustomTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if c.IsZero() {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{Name: name, Value: c.marshalText()}, nil
+}
+
+// FromCharData parses a dateTime from a character data token, collapsing
+// surrounding whitespace first.
+func FromCharData(data xml.CharData) (CustomTime, error) {
+	f, err := parse(collapse(string(data)))
+	if err != nil {
+		return CustomTime{}, err
+	}
+	return CustomTime{Time: f.time(), zone: f.zone}, nil
+}
+
+// ToCharData returns c as a character data token.
+func ToCharData(c CustomTime) xml.CharData {
+	return xml.CharData(c.marshalText())
+}
+
+// collapse applies the whitespace collapse facet of dateTime, which for a
+// valid value means trimming the surrounding XML whitespace.
+func collapse(s string) string {
+	return strings.Trim(s, " \t\r\n")
 }
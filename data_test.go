@@ -3,6 +3,8 @@ package xmldatetime
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -32,12 +34,85 @@ func TestParseIncorrect(t *testing.T) {
 	}
 }
 
+func TestParsePrefixes(t *testing.T) {
+	fullS := "-2017-08-16T13:07:00.1+02:00"
+	for i := 0; i < len(fullS); i++ {
+		v := fullS[:i]
+		_, err := Parse(v)
+		for _, f := range []ParseFunc{ParseRe, ParseRe2} {
+			if _, errF := f(v); (errF == nil) != (err == nil) {
+				t.Errorf("want same result as Parse for %q, got: %v, Parse: %v", v, errF, err)
+			}
+		}
+		if errV := Validate(v); (errV == nil) != (err == nil) {
+			t.Errorf("Validate(%q): want same result as Parse, got: %v, Parse: %v", v, errV, err)
+		}
+		if ok := ValidateFast([]byte(v)); ok != (err == nil) {
+			t.Errorf("ValidateFast(%q): want %v, got: %v", v, err == nil, ok)
+		}
+	}
+	if err := Validate(""); err == nil {
+		t.Errorf("Validate: want error for empty string, got nil")
+	}
+	if ValidateFast(nil) {
+		t.Errorf("ValidateFast: want false for nil, got true")
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	valid := []string{"2017-08-16T13:07:00Z", "2017-08-16T13:07:00.5+02:00", "2017-08-16T13:07:00"}
+	if i, err := ValidateAll(valid); i != -1 || err != nil {
+		t.Errorf("want -1, nil, got: %d, %v", i, err)
+	}
+	if i, err := ValidateAll(nil); i != -1 || err != nil {
+		t.Errorf("nil: want -1, nil, got: %d, %v", i, err)
+	}
+	bad := []string{"2017-08-16T13:07:00Z", "2017-08-16T13:07:00Z", "2017-02-30T13:07:00Z", "2017-08-16", "2017-08-16T13:07:00Z"}
+	i, err := ValidateAll(bad)
+	if want := `date "2017-02-30T13:07:00Z": day out of range for month`; i != 2 || err == nil || err.Error() != want {
+		t.Errorf("want 2, %s, got: %d, %v", want, i, err)
+	}
+	if n := testing.AllocsPerRun(10, func() { ValidateAll(valid) }); n != 0 {
+		t.Errorf("want 0 allocs, got: %v", n)
+	}
+}
+
+func TestParseAllocs(t *testing.T) {
+	for _, in := range []string{"2017-08-16T13:07:00.5", "2017-08-16T13:07:00.5Z", "2017-08-16T13:07:00.5+05:45"} {
+		b := []byte(in)
+		var c Components
+		for _, v := range []struct {
+			name string
+			f    func()
+		}{
+			{"Validate", func() { Validate(in) }},
+			{"ValidateFast", func() { ValidateFast(b) }},
+			{"ParseComponents", func() { ParseComponents(in) }},
+			{"ParseComponentsInto", func() { ParseComponentsInto(in, &c) }},
+		} {
+			if n := testing.AllocsPerRun(100, v.f); n != 0 {
+				t.Errorf("%s(%s): want 0 allocs, got: %v", v.name, in, n)
+			}
+		}
+	}
+	// an offset beyond the zone cache needs a new location for a time.Time
+	// only
+	d := NewDecoder(WithMaxOffsetHours(18))
+	if _, err := d.Parse("2017-08-16T13:07:00+17:45"); err != nil {
+		t.Errorf("error: %s", err)
+	}
+	if n := testing.AllocsPerRun(100, func() { d.parse("2017-08-16T13:07:00+17:45") }); n != 0 {
+		t.Errorf("fields: want 0 allocs, got: %v", n)
+	}
+}
+
 func TestParse(t *testing.T) {
 	for _, f := range []ParseFunc{Parse, ParseRe, ParseRe2} {
 		for _, v := range []string{
 			"2017-08-16T13:07:00.09251+02:00",
 			"2017-08-16T11:07:00.09251Z",
 			"2017-08-16T11:07:00.09251",
+			"2017-08-16T11:07:00.092510",
 		} {
 			tm, err := f(v)
 			if err != nil {
@@ -51,14 +126,317 @@ func TestParse(t *testing.T) {
 			}
 		}
 	}
+}
 
-	// error "2017-08-16T11:07:00.092510",
+func TestParseZeroFraction(t *testing.T) {
+	ex := time.Date(2017, time.August, 16, 13, 07, 0, 0, time.UTC)
+	for _, v := range []string{
+		"2017-08-16T13:07:00Z",
+		"2017-08-16T13:07:00.0Z",
+		"2017-08-16T13:07:00.00Z",
+	} {
+		tm, err := Parse(v)
+		if err != nil {
+			t.Errorf("%s: %s", v, err)
+			continue
+		}
+		if !tm.Equal(ex) || tm.Nanosecond() != 0 {
+			t.Errorf("%s: want: %s, got: %s", v, ex, tm)
+		}
+	}
+}
+
+func TestParseDotNetTicks(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		nsec int
+		out  string
+	}{
+		{"2017-08-16T13:07:00.1234567Z", 123456700, "2017-08-16T13:07:00.1234567Z"},
+		{"2017-08-16T13:07:00.1234560Z", 123456000, "2017-08-16T13:07:00.123456Z"},
+		{"2017-08-16T13:07:00.0000001+02:00", 100, "2017-08-16T13:07:00.0000001+02:00"},
+		{"2017-08-16T13:07:00.9999999", 999999900, "2017-08-16T13:07:00.9999999"},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if c.Nanosecond() != v.nsec {
+			t.Errorf("%s: want: %dns, got: %dns", v.in, v.nsec, c.Nanosecond())
+		}
+		out, _ := c.Value()
+		if out != v.out {
+			t.Errorf("%s: want: %s, got: %v", v.in, v.out, out)
+		}
+		var back CustomTime
+		if err := back.Scan(out); err != nil || back.Nanosecond() != v.nsec {
+			t.Errorf("%s: round trip: want: %dns, got: %dns, %v", v.in, v.nsec, back.Nanosecond(), err)
+		}
+	}
+}
+
+func TestParseFractionRounding(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want time.Time
+	}{
+		{"2017-08-16T13:07:00.9999999999999999999Z", time.Date(2017, time.August, 16, 13, 07, 1, 0, time.UTC)},
+		{"2017-08-16T23:59:59.9999999995Z", time.Date(2017, time.August, 17, 0, 0, 0, 0, time.UTC)},
+		{"2017-08-16T13:07:00.1234567894Z", time.Date(2017, time.August, 16, 13, 07, 0, 123456789, time.UTC)},
+		{"2017-08-16T13:07:00.1234567885Z", time.Date(2017, time.August, 16, 13, 07, 0, 123456789, time.UTC)},
+	} {
+		tm, err := Parse(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if !tm.Equal(v.want) {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, tm)
+		}
+	}
+}
+
+func TestParseTooLong(t *testing.T) {
+	long := "2017-08-16T13:07:00." + strings.Repeat("1", 1<<20) + "Z"
+	for _, f := range []ParseFunc{Parse, ParseRe, ParseRe2} {
+		if _, err := f(long); !errors.Is(err, errTooLong) {
+			t.Errorf("want: %v, got: %v", errTooLong, err)
+		}
+	}
+	if err := Validate(long); !errors.Is(err, errTooLong) {
+		t.Errorf("Validate: want: %v, got: %v", errTooLong, err)
+	}
+	if _, err := Parse("2017-08-16T13:07:00." + strings.Repeat("1", 37) + "+02:00"); err != nil {
+		t.Errorf("want 37 digit fraction accepted, got: %s", err)
+	}
+}
+
+func TestParseFractionalSecondBounds(t *testing.T) {
+	for n := 1; n <= 40; n++ {
+		for _, d := range []string{"9", "5", "1"} {
+			nsec, rest, err := parseFractionalSecond(strings.Repeat(d, n) + "Z")
+			if err != nil || rest != "Z" {
+				t.Errorf("%d x %s: %v, rest: %q", n, d, err, rest)
+				continue
+			}
+			if nsec < 0 || nsec > 1e9 || int64(nsec) != int64(int32(nsec)) {
+				t.Errorf("%d x %s: nanoseconds out of range: %d", n, d, nsec)
+			}
+		}
+	}
+	if nsec, _, _ := parseFractionalSecond("9999999999"); nsec != 1e9 {
+		t.Errorf("want: %d, got: %d", int(1e9), nsec)
+	}
+	if nsec, _, _ := parseFractionalSecond("1234567894999"); nsec != 123456789 {
+		t.Errorf("want: 123456789, got: %d", nsec)
+	}
+}
+
+func TestParseMaxNanoseconds(t *testing.T) {
+	for _, v := range []struct {
+		in     string
+		second int
+	}{
+		{"2017-08-16T13:07:00.999999999Z", 0},
+		{"2017-08-16T13:07:59.999999999Z", 59},
+	} {
+		tm, err := Parse(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if tm.Nanosecond() != 999999999 || tm.Second() != v.second || tm.Minute() != 7 {
+			t.Errorf("%s: want :%02d.999999999 without carry, got: %s", v.in, v.second, tm)
+		}
+	}
+}
+
+func TestParseIn(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no zoneinfo: %s", err)
+	}
+	tm, err := ParseIn("2017-08-16T13:07:00+02:00", loc)
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if tm.Location() != loc {
+		t.Errorf("want location: %s, got: %s", loc, tm.Location())
+	}
+	if tm.Hour() != 7 {
+		t.Errorf("want hour: 7, got: %d", tm.Hour())
+	}
+	ex := time.Date(2017, time.August, 16, 11, 07, 0, 0, time.UTC)
+	if !tm.Equal(ex) {
+		t.Errorf("want: %s, got: %s", ex, tm)
+	}
+}
+
+func TestParseOffsetMinute(t *testing.T) {
+	for _, f := range []ParseFunc{Parse, ParseRe, ParseRe2} {
+		_, err := f("2017-08-16T13:07:00+13:60")
+		if err == nil || !strings.Contains(err.Error(), "minute") {
+			t.Errorf("want timezone minute error, got: %v", err)
+		}
+		tm, err := f("2017-08-16T13:07:00+14:00")
+		if err != nil {
+			t.Errorf("error: %s", err)
+			continue
+		}
+		if _, offset := tm.Zone(); offset != 14*60*60 {
+			t.Errorf("want offset: %d, got: %d", 14*60*60, offset)
+		}
+	}
+}
+
+func TestParseOffsetBoundary(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+		offset   int
+	}{
+		{"2017-08-16T13:07:00+14:00", "", 14 * 60 * 60},
+		{"2017-08-16T13:07:00-14:00", "", -14 * 60 * 60},
+		{"2017-08-16T13:07:00+13:59", "", (13*60 + 59) * 60},
+		{"2017-08-16T13:07:00-05:30", "", -(5*60 + 30) * 60},
+		{"2017-08-16T13:07:00+14:01", "timezone minute must be 00 when the hour is 14", 0},
+		{"2017-08-16T13:07:00-14:59", "timezone minute must be 00 when the hour is 14", 0},
+		{"2017-08-16T13:07:00+15:00", "max timezone hour is 14", 0},
+		{"2017-08-16T13:07:00-15:00", "max timezone hour is 14", 0},
+	} {
+		for _, f := range []ParseFunc{Parse, ParseRe, ParseRe2} {
+			tm, err := f(v.in)
+			if v.want == "" {
+				if err != nil {
+					t.Errorf("%s: %s", v.in, err)
+				} else if _, offset := tm.Zone(); offset != v.offset {
+					t.Errorf("%s: want offset: %d, got: %d", v.in, v.offset, offset)
+				}
+				continue
+			}
+			if err == nil || errMsg(err) != v.want {
+				t.Errorf("%s: want error: %s, got: %v", v.in, v.want, err)
+			}
+		}
+	}
+}
+
+func TestParseFieldWidth(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-8-16T13:07:00Z", "expected two-digit month"},
+		{"2017-08-6T13:07:00Z", "expected two-digit day"},
+		{"2017-08-16T1:07:00Z", "expected two-digit hour"},
+		{"17-08-16T13:07:00Z", "expected four-digit year"},
+	} {
+		_, err := Parse(v.in)
+		if err == nil || errMsg(err) != v.want {
+			t.Errorf("%s: want error: %s, got: %v", v.in, v.want, err)
+		}
+	}
+}
+
+func TestParseFieldSign(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-0+-16T13:07:00Z", "expected two-digit month"},
+		{"2017-+8-16T13:07:00Z", "expected two-digit month"},
+		{"2017-08--6T13:07:00Z", "expected two-digit day"},
+		{"2017-08-+6T13:07:00Z", "expected two-digit day"},
+		{"2017-08-16T+3:07:00Z", "expected two-digit hour"},
+		{"2017-08-16T1-:07:00Z", "expected two-digit hour"},
+		{"2017-08-16T13:-7:00Z", "expected two-digit minute"},
+		{"2017-08-16T13:07:+0Z", "expected two-digit second"},
+		{"20+7-08-16T13:07:00Z", "expected four-digit year"},
+		{"2017-08-16T13:07:00+-2:00", "expected two-digit timezone hour"},
+		{"2017-08-16T13:07:00+02:+0", "expected two-digit timezone minute"},
+	} {
+		_, err := Parse(v.in)
+		if err == nil || errMsg(err) != v.want {
+			t.Errorf("%s: want error: %s, got: %v", v.in, v.want, err)
+		}
+		for _, f := range []ParseFunc{ParseRe, ParseRe2} {
+			if _, err := f(v.in); err == nil {
+				t.Errorf("%s: want regexp parser error, got nil", v.in)
+			}
+		}
+	}
+}
+
+func TestParseTrailingControl(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-08-16T13:07:00Z\x00", `unexpected trailing byte '\x00'`},
+		{"2017-08-16T13:07:00+02:00\x00", `unexpected trailing byte '\x00'`},
+		{"2017-08-16T13:07:00\x1f", `unexpected trailing byte '\x1f'`},
+		{"2017-08-16T13:07:00.5\x7f", `unexpected trailing byte '\x7f'`},
+		{"2017-08-16T13:07:00Z\n", `unexpected trailing byte '\n'`},
+	} {
+		_, err := Parse(v.in)
+		if err == nil || errMsg(err) != v.want {
+			t.Errorf("%q: want error: %s, got: %v", v.in, v.want, err)
+		}
+		if err := Validate(v.in); err == nil {
+			t.Errorf("%q: want Validate error, got nil", v.in)
+		}
+	}
+	if _, err := NewLenient().Parse("2017-08-16T13:07:00Z\n"); err != nil {
+		t.Errorf("lenient: %s", err)
+	}
+}
+
+func TestParseMissingDate(t *testing.T) {
+	for _, v := range []string{"Z", "+02:00", "-02:00", "T13:07:00Z"} {
+		_, err := Parse(v)
+		if err == nil || errMsg(err) != "missing date component" {
+			t.Errorf("%s: want missing date component error, got: %v", v, err)
+		}
+		if Validate(v) == nil {
+			t.Errorf("%s: want Validate error, got nil", v)
+		}
+	}
+}
+
+func TestParseFieldRange(t *testing.T) {
+	for _, v := range []string{
+		"2017-00-16T13:07:00Z",
+		"2017-13-16T13:07:00Z",
+		"2017-08-00T13:07:00Z",
+		"2017-08-32T13:07:00Z",
+		"2017-02-29T13:07:00Z",
+		"2017-04-31T13:07:00Z",
+		"2017-08-16T25:07:00Z",
+		"2017-08-16T24:00:01Z",
+		"2017-08-16T24:00:00.5Z",
+		"2017-08-16T24:00:00.0000000001Z",
+		"2017-08-16T13:60:00Z",
+		"2017-08-16T13:07:61Z",
+	} {
+		if _, err := Parse(v); err == nil {
+			t.Errorf("want error, got nil: %s", v)
+		}
+	}
+	for _, v := range []string{
+		"2016-02-29T13:07:00Z",
+		"2000-02-29T13:07:00Z",
+		"2017-08-16T24:00:00Z",
+		"2017-08-16T24:00:00.000000000000Z",
+		"2017-08-16T23:59:60Z",
+	} {
+		if _, err := Parse(v); err != nil {
+			t.Errorf("%s: %s", v, err)
+		}
+	}
 }
 
 func TestStringify(t *testing.T) {
 	ex := time.Date(2017, time.August, 16, 11, 07, 0, 92510000, time.UTC)
-	if s := stringify(ex); s != "2017-08-16T11:07:00.09251" {
-		t.Errorf("want: 2017-08-16T11:07:00.09251, got: %s", s)
+	if s := stringify(ex); s != "2017-08-16T11:07:00.09251Z" {
+		t.Errorf("want: 2017-08-16T11:07:00.09251Z, got: %s", s)
 		t.FailNow()
 	}
 
@@ -77,7 +455,7 @@ func TestStringify(t *testing.T) {
 
 func TestCustomTime_MarshalXML(t *testing.T) {
 	ex := time.Date(2017, time.August, 16, 13, 07, 0, 92510000, time.FixedZone("+02:00", 2*60*60))
-	c := CustomTime{ex}
+	c := CustomTime{Time: ex}
 	got, err := xml.Marshal(c)
 	if err != nil {
 		t.Errorf("marshaling: %s", err)
@@ -109,12 +487,366 @@ func TestCustomTime_UnmarshalXML(t *testing.T) {
 	}
 }
 
+func TestCustomTime_UnmarshalXMLPointer(t *testing.T) {
+	type doc struct {
+		T *CustomTime `xml:"t"`
+	}
+	var v doc
+	if err := xml.Unmarshal([]byte("<r></r>"), &v); err != nil || v.T != nil {
+		t.Errorf("absent: want nil, got: %v, %v", v.T, err)
+	}
+	for _, in := range []string{"<r><t/></r>", "<r><t> </t></r>"} {
+		v = doc{}
+		if err := xml.Unmarshal([]byte(in), &v); err != nil {
+			t.Errorf("%s: %s", in, err)
+			continue
+		}
+		if v.T == nil || !v.T.IsZero() {
+			t.Errorf("%s: want zero value, got: %v", in, v.T)
+		}
+	}
+	v = doc{}
+	if err := xml.Unmarshal([]byte("<r><t>2017-08-16T13:07:00+02:00</t></r>"), &v); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	ex := time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC)
+	if v.T == nil || !v.T.Time.Equal(ex) {
+		t.Errorf("want: %s, got: %v", ex, v.T)
+	}
+	if err := xml.Unmarshal([]byte("<r><t>x</t></r>"), &v); err == nil {
+		t.Errorf("want error, got nil")
+	}
+}
+
+func TestCustomTime_IsZero(t *testing.T) {
+	var parsed CustomTime
+	if err := xml.Unmarshal([]byte("<t>0001-01-01T00:00:00Z</t>"), &parsed); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if !parsed.Time.IsZero() || parsed.IsZero() {
+		t.Errorf("want parsed minimal date not to be zero, got: %v", parsed.IsZero())
+	}
+	got, err := xml.Marshal(&parsed)
+	if err != nil || string(got) != "<CustomTime>0001-01-01T00:00:00Z</CustomTime>" {
+		t.Errorf("want: <CustomTime>0001-01-01T00:00:00Z</CustomTime>, got: %s, %v", got, err)
+	}
+
+	var unset CustomTime
+	if !unset.IsZero() {
+		t.Errorf("want unset value to be zero")
+	}
+	got, err = xml.Marshal(&unset)
+	if err != nil || string(got) != "<CustomTime></CustomTime>" {
+		t.Errorf("want: <CustomTime></CustomTime>, got: %s, %v", got, err)
+	}
+	if c := (CustomTime{Time: time.Date(2017, time.August, 16, 0, 0, 0, 0, time.UTC)}); c.IsZero() {
+		t.Errorf("want constructed value not to be zero")
+	}
+}
+
+func TestCustomTime_IsZoneless(t *testing.T) {
+	for _, v := range []struct {
+		in       string
+		zoneless bool
+	}{
+		{"2017-08-16T11:07:00.09251", true},
+		{"2017-08-16T11:07:00.09251Z", false},
+		{"2017-08-16T09:07:00.09251-02:00", false},
+		{"2017-08-16T13:07:00.09251+02:00", false},
+	} {
+		c := CustomTime{}
+		if err := xml.Unmarshal([]byte("<t>"+v.in+"</t>"), &c); err != nil {
+			t.Errorf("problem with unmarshal: %s", err)
+			continue
+		}
+		if c.IsZoneless() != v.zoneless {
+			t.Errorf("%s: want zoneless: %v, got: %v", v.in, v.zoneless, c.IsZoneless())
+		}
+		got, err := xml.Marshal(&c)
+		if err != nil {
+			t.Errorf("marshaling: %s", err)
+			continue
+		}
+		if want := "<CustomTime>" + v.in + "</CustomTime>"; string(got) != want {
+			t.Errorf("want: %s, got: %s", want, got)
+		}
+	}
+}
+
+func TestCustomTime_OffsetString(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-08-16T11:07:00", ""},
+		{"2017-08-16T11:07:00Z", "Z"},
+		{"2017-08-16T13:07:00+02:00", "+02:00"},
+		{"2017-08-16T01:37:00-09:30", "-09:30"},
+		{"2017-08-16T11:07:00+00:00", "+00:00"},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := c.OffsetString(); got != v.want {
+			t.Errorf("%s: want: %q, got: %q", v.in, v.want, got)
+		}
+	}
+	c := CustomTime{Time: time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC)}
+	if got := c.OffsetString(); got != "Z" {
+		t.Errorf("want: Z, got: %q", got)
+	}
+}
+
+func TestCustomTime_OffsetMinutes(t *testing.T) {
+	for _, v := range []struct {
+		in      string
+		want    int
+		present bool
+	}{
+		{"2017-08-16T13:07:00+05:30", 330, true},
+		{"2017-08-16T01:37:00-09:30", -570, true},
+		{"2017-08-16T11:07:00Z", 0, true},
+		{"2017-08-16T11:07:00", 0, false},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got, ok := c.OffsetMinutes(); got != v.want || ok != v.present {
+			t.Errorf("%s: want: %d, %v, got: %d, %v", v.in, v.want, v.present, got, ok)
+		}
+	}
+}
+
+func TestFromCharData(t *testing.T) {
+	dec := xml.NewDecoder(strings.NewReader("<a>at <t>\n  2017-08-16T13:07:00.09251+02:00 </t> end</a>"))
+	var got []CustomTime
+	inT := false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			inT = tok.Name.Local == "t"
+		case xml.EndElement:
+			inT = false
+		case xml.CharData:
+			if !inT {
+				continue
+			}
+			c, err := FromCharData(tok)
+			if err != nil {
+				t.Errorf("error: %s", err)
+				t.FailNow()
+			}
+			got = append(got, c)
+		}
+	}
+	if len(got) != 1 {
+		t.Errorf("want 1 value, got: %d", len(got))
+		t.FailNow()
+	}
+	ex := time.Date(2017, time.August, 16, 11, 07, 0, 92510000, time.UTC)
+	if !got[0].Time.Equal(ex) {
+		t.Errorf("want: %s, got: %s", ex, got[0].Time)
+	}
+	if cd := string(ToCharData(got[0])); cd != "2017-08-16T13:07:00.09251+02:00" {
+		t.Errorf("want: 2017-08-16T13:07:00.09251+02:00, got: %s", cd)
+	}
+
+	if _, err := FromCharData(xml.CharData("2017-08-16 13:07:00")); err == nil {
+		t.Errorf("want error, got nil")
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	for _, v := range []string{
+		"2017-08-16T13:07:00.09251+02:00",
+		"2017-08-16T11:07:00.09251Z",
+		"2017-08-16",
+		"",
+	} {
+		want, wantErr := Parse(v)
+		got, err := ParseBytes([]byte(v))
+		if (err == nil) != (wantErr == nil) || !got.Equal(want) {
+			t.Errorf("%q: want: %s, %v, got: %s, %v", v, want, wantErr, got, err)
+		}
+	}
+}
+
+func TestCustomTime_UnmarshalXMLContent(t *testing.T) {
+	for _, in := range []string{
+		"<t>2017-08-16T13:07:00.09251+02:00</t>",
+		"<t>\n\t2017-08-16T13:07:00.09251+02:00 </t>",
+		"<t><!-- c -->2017-08-16T13:07:00<![CDATA[.09251+02:00]]></t>",
+		"<t>2017-08-16T13:07:00.09251+02:00<x>skipped</x></t>",
+	} {
+		var c CustomTime
+		if err := xml.Unmarshal([]byte(in), &c); err != nil {
+			t.Errorf("%s: %s", in, err)
+			continue
+		}
+		ex := time.Date(2017, time.August, 16, 11, 07, 0, 92510000, time.UTC)
+		if !c.Time.Equal(ex) {
+			t.Errorf("%s: want: %s, got: %s", in, ex, c.Time)
+		}
+	}
+}
+
+func TestCustomTime_UnmarshalXMLAttr(t *testing.T) {
+	type doc struct {
+		T CustomTime  `xml:"t,attr"`
+		P *CustomTime `xml:"p,attr,omitempty"`
+	}
+	ex := time.Date(2017, time.August, 16, 11, 07, 0, 92510000, time.UTC)
+	for _, in := range []string{
+		`<r t="2017-08-16T13:07:00.09251+02:00"/>`,
+		`<r t="  2017-08-16T13:07:00.09251+02:00  "/>`,
+		"<r t=\"\n\t2017-08-16T13:07:00.09251+02:00\n\"/>",
+		`<r t="&#10; 2017-08-16T13:07:00.09251+02:00&#13;&#10;"/>`,
+	} {
+		var v doc
+		if err := xml.Unmarshal([]byte(in), &v); err != nil {
+			t.Errorf("%s: %s", in, err)
+			continue
+		}
+		if !v.T.Time.Equal(ex) {
+			t.Errorf("%s: want: %s, got: %s", in, ex, v.T.Time)
+		}
+		var el CustomTime
+		elem := strings.Replace(strings.Replace(in, `<r t="`, "<t>", 1), `"/>`, "</t>", 1)
+		if err := xml.Unmarshal([]byte(elem), &el); err != nil || !el.Time.Equal(v.T.Time) {
+			t.Errorf("%s: element and attribute differ: %s, %v", in, el.Time, err)
+		}
+	}
+	if err := xml.Unmarshal([]byte(`<r t="2017-08-16 13:07:00Z"/>`), &doc{}); err == nil {
+		t.Errorf("want error, got nil")
+	}
+
+	v := doc{T: CustomTime{Time: ex}}
+	got, err := xml.Marshal(&v)
+	if err != nil {
+		t.Errorf("marshaling: %s", err)
+		t.FailNow()
+	}
+	if want := `<doc t="2017-08-16T11:07:00.09251Z"></doc>`; string(got) != want {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+}
+
+func TestParseTrailingDot(t *testing.T) {
+	for _, v := range []string{
+		"2017-08-16T13:07:00.",
+		"2017-08-16T13:07:00.Z",
+		"2017-08-16T13:07:00.+02:00",
+	} {
+		for _, p := range []struct {
+			name  string
+			parse ParseFunc
+		}{{"Parse", Parse}, {"ParseDateTimeStamp", ParseDateTimeStamp}, {"ParseRe", ParseRe}} {
+			_, err := p.parse(v)
+			if p.name == "ParseRe" {
+				if err == nil {
+					t.Errorf("%s(%s): want error, got nil", p.name, v)
+				}
+				continue
+			}
+			if !errors.Is(err, ErrFractionDigits) {
+				t.Errorf("%s(%s): want ErrFractionDigits, got: %v", p.name, v, err)
+			}
+		}
+		if err := Validate(v); !errors.Is(err, ErrFractionDigits) {
+			t.Errorf("Validate(%s): want ErrFractionDigits, got: %v", v, err)
+		}
+	}
+	if _, err := ParseTime("13:07:00."); !errors.Is(err, ErrFractionDigits) {
+		t.Errorf("ParseTime: want ErrFractionDigits, got: %v", err)
+	}
+}
+
+func FuzzParseBytesEquivalence(f *testing.F) {
+	for _, v := range []string{
+		"2017-08-16T13:07:00.09251+02:00",
+		"2017-08-16T11:07:00.09251Z",
+		"2017-08-16T11:07:00.09251",
+		"-2017-08-16T13:07:00.1+02:00",
+		"2017-08-16T24:00:00Z",
+		"2017-08-16T23:59:60Z",
+		"2017-08-16T23:59:59.9999999995Z",
+		"2017-08-16T13:07:00.",
+		"2017-0+-16T13:07:00Z",
+		"2017-08-16T13:07:00+14:01",
+		"",
+	} {
+		f.Add(v)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		a, errA := Parse(s)
+		b, errB := ParseBytes([]byte(s))
+		if (errA == nil) != (errB == nil) {
+			t.Fatalf("%q: Parse error: %v, ParseBytes error: %v", s, errA, errB)
+		}
+		if !a.Equal(b) {
+			t.Fatalf("%q: Parse: %s, ParseBytes: %s", s, a, b)
+		}
+	})
+}
+
+func BenchmarkUnmarshalXML(b *testing.B) {
+	b.ReportAllocs()
+	data := []byte("<r><t>2017-08-16T13:07:00.09251+02:00</t></r>")
+	var v struct {
+		T CustomTime `xml:"t"`
+	}
+	for i := 0; i < b.N; i++ {
+		xml.Unmarshal(data, &v)
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Parse("2017-08-16T13:07:00.09251+02:00")
 	}
 }
 
+func BenchmarkParseMatrix(b *testing.B) {
+	zones := []struct{ name, s string }{{"NoZone", ""}, {"Z", "Z"}, {"Offset", "+02:00"}}
+	fractions := []struct{ name, s string }{{"Frac0", ""}, {"Frac3", ".092"}, {"Frac6", ".092510"}, {"Frac9", ".092510123"}}
+	for _, z := range zones {
+		for _, f := range fractions {
+			in := "2017-08-16T13:07:00" + f.s + z.s
+			b.Run("Parse/"+z.name+"/"+f.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := Parse(in); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			bs := []byte(in)
+			b.Run("ParseBytes/"+z.name+"/"+f.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := ParseBytes(bs); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkParseTooLong(b *testing.B) {
+	long := "2017-08-16T13:07:00." + strings.Repeat("1", 1<<20) + "Z"
+	for i := 0; i < b.N; i++ {
+		ParseRe(long)
+	}
+}
+
 func BenchmarkParseRe(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		ParseRe("2017-08-16T13:07:00.09251+02:00")
@@ -126,3 +858,13 @@ func BenchmarkParseRe2(b *testing.B) {
 		ParseRe2("2017-08-16T13:07:00.09251+02:00")
 	}
 }
+
+// errMsg returns the message of err without the field and the input a
+// ParseError adds.
+func errMsg(err error) string {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		return pe.Err.Error()
+	}
+	return err.Error()
+}
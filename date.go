@@ -0,0 +1,186 @@
+package xmldatetime
+
+import (
+	"errors"
+	"time"
+)
+
+// DateOnly is an xs:date value, kept as midnight of the date.
+type DateOnly struct {
+	time.Time
+	zone ZoneForm
+}
+
+// TimeOnly is an xs:time value, kept as the clock time on January 1 of
+// year 0.
+type TimeOnly struct {
+	time.Time
+}
+
+// MarshalText implements encoding.TextMarshaler, emitting the xs:date
+// lexical form with its timezone, none for a value parsed without one. It
+// is also used for XML elements and attributes. The zero value, as from
+// empty text, is emitted as empty text.
+func (d DateOnly) MarshalText() ([]byte, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	y, m, day := d.Date()
+	b := appendDate(make([]byte, 0, 16), y, int(m), day)
+	if d.zone == ZoneNone {
+		return b, nil
+	}
+	return appendZone(b, d.Time, nil), nil
+}
+
+// IsZero reports whether d is the zero value, which a date parsed as
+// 0001-01-01 is not.
+func (d DateOnly) IsZero() bool {
+	return d.zone == 0 && d.Time.IsZero()
+}
+
+// IsZoneless reports whether d was parsed from a date without timezone.
+func (d DateOnly) IsZoneless() bool {
+	return d.zone == ZoneNone
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing an xs:date
+// after collapsing surrounding whitespace. Empty text leaves the zero
+// value.
+func (d *DateOnly) UnmarshalText(b []byte) error {
+	s := collapse(string(b))
+	if s == "" {
+		*d = DateOnly{}
+		return nil
+	}
+	f, err := parseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = DateOnly{Time: f.time(), zone: f.zone}
+	return nil
+}
+
+// SplitDateTime returns the calendar and the clock parts of c as seen in
+// its own location. A clock time alone cannot resolve daylight saving
+// rules, so both parts get a fixed zone with the offset c has at its
+// instant; UTC values stay in time.UTC.
+func (c CustomTime) SplitDateTime() (DateOnly, TimeOnly) {
+	loc := fixedLocation(c.Time)
+	return DateOnly{Time: time.Date(c.Year(), c.Month(), c.Day(), 0, 0, 0, 0, loc), zone: c.zone},
+		TimeOnly{time.Date(0, time.January, 1, c.Hour(), c.Minute(), c.Second(), c.Nanosecond(), loc)}
+}
+
+// UTCDate returns the date of the instant of c in UTC, e.g. 2017-08-17 for
+// 2017-08-16T23:00:00-05:00. It is the UTC calendar day, not the one of
+// the location of c, so that values from any offset bucket together.
+func (c CustomTime) UTCDate() DateOnly {
+	y, m, d := c.Time.UTC().Date()
+	return DateOnly{Time: time.Date(y, m, d, 0, 0, 0, 0, time.UTC), zone: ZoneUTC}
+}
+
+// fixedLocation returns a fixed zone with the offset t has at its instant,
+// from the cache of fixedZone.
+func fixedLocation(t time.Time) *time.Location {
+	_, offset := t.Zone()
+	return fixedZone(offset)
+}
+
+// ParseDate parses the xs:date lexical representation
+// '-'? yyyy '-' mm '-' dd (zzzzzz)? into midnight of the date in its
+// timezone, UTC when there is none.
+func ParseDate(s string) (time.Time, error) {
+	f, err := parseDate(s)
+	if err != nil {
+		return not, err
+	}
+	return f.time(), nil
+}
+
+func parseDate(s string) (fields, error) {
+	var f fields
+	if len(s) == 0 {
+		return f, errors.New("empty date")
+	}
+	if len(s) > maxLength {
+		return f, errTooLong
+	}
+	rest, err := f.lexDate(s)
+	if err != nil {
+		return f, err
+	}
+	if len(rest) > 0 && (rest[0] == 'T' || rest[0] == 't' || rest[0] == ' ') {
+		return f, errors.New("date type does not allow time component")
+	}
+	if f.offset, f.zone, err = defaultDecoder.parseOffset(rest); err != nil {
+		return f, err
+	}
+	return f, f.checkDate()
+}
+
+// ParseTime parses the xs:time lexical representation
+// hh ':' mm ':' ss ('.' s+)? (zzzzzz)? into the clock time on January 1 of
+// year 0 in its timezone, UTC when there is none.
+func ParseTime(s string) (time.Time, error) {
+	f, err := parseTime(s)
+	if err != nil {
+		return not, err
+	}
+	return f.time(), nil
+}
+
+func parseTime(s string) (fields, error) {
+	f := fields{month: 1, day: 1}
+	if len(s) == 0 {
+		return f, errors.New("empty time")
+	}
+	if len(s) > maxLength {
+		return f, errTooLong
+	}
+	rest, err := f.lexTime(s)
+	if err != nil {
+		return f, err
+	}
+	if f.offset, f.zone, err = defaultDecoder.parseOffset(rest); err != nil {
+		return f, err
+	}
+	return f, f.checkTime()
+}
+
+// Combine parses an xs:date and an xs:time and joins them into a dateTime.
+// A timezone on either applies to the result; timezones on both must be
+// the same offset. Without any the result is zoneless, in UTC.
+func Combine(dateStr, timeStr string) (time.Time, error) {
+	d, err := parseDate(dateStr)
+	if err != nil {
+		return not, err
+	}
+	c, err := parseTime(timeStr)
+	if err != nil {
+		return not, err
+	}
+	if d.zone != ZoneNone && c.zone != ZoneNone && d.offset != c.offset {
+		return not, errors.New("date and time have different timezones")
+	}
+	c.year, c.month, c.day = d.year, d.month, d.day
+	if c.zone == ZoneNone {
+		c.offset, c.zone = d.offset, d.zone
+	}
+	return c.time(), nil
+}
+
+// FormatDate returns the date of t in the xs:date lexical representation,
+// with its timezone written per opts. A time.Time always has a zone, so a
+// date parsed without one gets 'Z'; DateOnly keeps it zoneless.
+func FormatDate(t time.Time, opts ...FormatOption) string {
+	y, m, d := t.Date()
+	return string(appendZone(appendDate(make([]byte, 0, 16), y, int(m), d), t, opts))
+}
+
+// FormatTime returns the clock of t in the xs:time lexical representation,
+// with its timezone written per opts.
+func FormatTime(t time.Time, opts ...FormatOption) string {
+	h, m, s := t.Clock()
+	b := appendFraction(appendClock(make([]byte, 0, 24), h, m, s), t.Nanosecond())
+	return string(appendZone(b, t, opts))
+}
@@ -0,0 +1,223 @@
+package xmldatetime
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestCustomTime_SplitDateTime(t *testing.T) {
+	tm, err := Parse("2017-08-16T13:07:00+02:00")
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	d, tt := CustomTime{Time: tm}.SplitDateTime()
+	if y, m, day := d.Date(); y != 2017 || m != time.August || day != 16 {
+		t.Errorf("want date: 2017-08-16, got: %d-%d-%d", y, m, day)
+	}
+	if d.Hour() != 0 || d.Minute() != 0 || d.Second() != 0 {
+		t.Errorf("want midnight, got: %s", d.Time)
+	}
+	if h, m, s := tt.Clock(); h != 13 || m != 7 || s != 0 {
+		t.Errorf("want clock: 13:07:00, got: %02d:%02d:%02d", h, m, s)
+	}
+	for _, part := range []time.Time{d.Time, tt.Time} {
+		if _, offset := part.Zone(); offset != 2*60*60 {
+			t.Errorf("want offset: %d, got: %d", 2*60*60, offset)
+		}
+	}
+
+	got := time.Date(d.Year(), d.Month(), d.Day(),
+		tt.Hour(), tt.Minute(), tt.Second(), tt.Nanosecond(), d.Location())
+	if !got.Equal(tm) {
+		t.Errorf("want: %s, got: %s", tm, got)
+	}
+
+	// the location comes from the shared cache
+	c := CustomTime{Time: tm}
+	if n := testing.AllocsPerRun(10, func() { c.SplitDateTime() }); n != 0 {
+		t.Errorf("want 0 allocs, got: %v", n)
+	}
+	if d2, _ := c.SplitDateTime(); d2.Location() != d.Location() {
+		t.Errorf("want the cached location")
+	}
+}
+
+func TestCustomTime_UTCDate(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-08-16T23:00:00-05:00", "2017-08-17Z"},
+		{"2017-08-17T01:30:00+05:30", "2017-08-16Z"},
+		{"2017-12-31T20:00:00-04:00", "2018-01-01Z"},
+		{"2017-08-16T13:07:00Z", "2017-08-16Z"},
+		{"2017-08-16T13:07:00", "2017-08-16Z"},
+	} {
+		tm, err := Parse(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := FormatDate(CustomTime{Time: tm}.UTCDate().Time); got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+	}
+}
+
+func TestParseFormatDate(t *testing.T) {
+	for _, v := range []struct {
+		in, want, text string
+	}{
+		{"2017-08-16", "2017-08-16Z", "2017-08-16"},
+		{"2017-08-16Z", "2017-08-16Z", "2017-08-16Z"},
+		{"2017-08-16+02:00", "2017-08-16+02:00", "2017-08-16+02:00"},
+		{"-0044-03-15-05:30", "-0044-03-15-05:30", "-0044-03-15-05:30"},
+	} {
+		tm, err := ParseDate(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if tm.Hour() != 0 || tm.Minute() != 0 || tm.Second() != 0 || tm.Nanosecond() != 0 {
+			t.Errorf("%s: want midnight, got: %s", v.in, tm)
+		}
+		got := FormatDate(tm)
+		if got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+		if back, err := ParseDate(got); err != nil || !back.Equal(tm) {
+			t.Errorf("%s: round trip: want: %s, got: %s, %v", v.in, tm, back, err)
+		}
+		var d DateOnly
+		if err := d.UnmarshalText([]byte(v.in)); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if text, _ := d.MarshalText(); string(text) != v.text {
+			t.Errorf("%s: DateOnly: want: %s, got: %s", v.in, v.text, text)
+		}
+	}
+	for _, v := range []string{"", "2017-8-16", "2017-02-30", "2017-08-16+15:00", "2017-08"} {
+		if _, err := ParseDate(v); err == nil {
+			t.Errorf("want error, got nil: %q", v)
+		}
+	}
+	for _, v := range []string{"2017-08-16T00:00:00Z", "2017-08-16T00:00:00", "2017-08-16T", "2017-08-16 00:00:00"} {
+		_, err := ParseDate(v)
+		if err == nil || err.Error() != "date type does not allow time component" {
+			t.Errorf("%q: want time component error, got: %v", v, err)
+		}
+	}
+	tm := time.Date(2017, time.August, 16, 13, 7, 0, 5e8, time.FixedZone("", 2*60*60))
+	if got := FormatDate(tm); got != "2017-08-16+02:00" {
+		t.Errorf("want: 2017-08-16+02:00, got: %s", got)
+	}
+}
+
+func TestParseFormatTime(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"13:07:00", "13:07:00Z"},
+		{"13:07:00.500Z", "13:07:00.5Z"},
+		{"13:07:00.09251+02:00", "13:07:00.09251+02:00"},
+		{"00:00:00-09:30", "00:00:00-09:30"},
+	} {
+		tm, err := ParseTime(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if y, m, d := tm.Date(); y != 0 || m != time.January || d != 1 {
+			t.Errorf("%s: want 0000-01-01, got: %s", v.in, tm)
+		}
+		got := FormatTime(tm)
+		if got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+		if back, err := ParseTime(got); err != nil || !back.Equal(tm) {
+			t.Errorf("%s: round trip: want: %s, got: %s, %v", v.in, tm, back, err)
+		}
+	}
+	for _, v := range []string{"", "13:07", "25:00:00", "13:07:00.", "13:07:00+14:30"} {
+		if _, err := ParseTime(v); err == nil {
+			t.Errorf("want error, got nil: %q", v)
+		}
+	}
+	tm := time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC)
+	if got := FormatTime(tm); got != "13:07:00Z" {
+		t.Errorf("want: 13:07:00Z, got: %s", got)
+	}
+}
+
+func TestCombine(t *testing.T) {
+	for _, v := range []struct {
+		date, time, want string
+	}{
+		{"2017-08-16", "13:07:00", "2017-08-16T13:07:00Z"},
+		{"2017-08-16+02:00", "13:07:00.5+02:00", "2017-08-16T13:07:00.5+02:00"},
+		{"2017-08-16Z", "13:07:00+00:00", "2017-08-16T13:07:00Z"},
+		{"2017-08-16-05:30", "13:07:00", "2017-08-16T13:07:00-05:30"},
+		{"2017-08-16", "13:07:00+02:00", "2017-08-16T13:07:00+02:00"},
+		{"2017-12-31", "24:00:00", "2018-01-01T00:00:00Z"},
+	} {
+		tm, err := Combine(v.date, v.time)
+		if err != nil {
+			t.Errorf("%s %s: %s", v.date, v.time, err)
+			continue
+		}
+		if got := Format(tm); got != v.want {
+			t.Errorf("%s %s: want: %s, got: %s", v.date, v.time, v.want, got)
+		}
+	}
+	for _, v := range [][2]string{
+		{"2017-08-16+02:00", "13:07:00+01:00"},
+		{"2017-08-16Z", "13:07:00-05:00"},
+		{"2017-08-16T13:07:00", "13:07:00"},
+		{"2017-08-16", "13:07"},
+	} {
+		if _, err := Combine(v[0], v[1]); err == nil {
+			t.Errorf("%s %s: want error, got nil", v[0], v[1])
+		}
+	}
+}
+
+func TestDateOnly_MarshalXML(t *testing.T) {
+	type doc struct {
+		D  DateOnly `xml:"d"`
+		At DateOnly `xml:"at,attr"`
+	}
+	in := `<doc at="2017-08-17Z"><d> 2017-08-16+02:00 </d></doc>`
+	var v doc
+	if err := xml.Unmarshal([]byte(in), &v); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	got, err := xml.Marshal(v)
+	if err != nil {
+		t.Errorf("marshaling: %s", err)
+		t.FailNow()
+	}
+	if want := `<doc at="2017-08-17Z"><d>2017-08-16+02:00</d></doc>`; string(got) != want {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+
+	in = `<doc at="2017-08-17"><d/></doc>`
+	v = doc{}
+	if err := xml.Unmarshal([]byte(in), &v); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if !v.D.IsZero() || !v.At.IsZoneless() {
+		t.Errorf("want zero d and zoneless at, got: %s, %s", v.D.Time, v.At.Time)
+	}
+	got, err = xml.Marshal(v)
+	if err != nil {
+		t.Errorf("marshaling: %s", err)
+		t.FailNow()
+	}
+	if want := `<doc at="2017-08-17"><d></d></doc>`; string(got) != want {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+}
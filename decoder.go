@@ -0,0 +1,441 @@
+package xmldatetime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Decoder parses dateTime values with a fixed set of options. The zero
+// value behaves like Parse.
+type Decoder struct {
+	epochUnit      time.Duration
+	hourOnlyOffset bool
+
+	requirePrecision bool
+	precision        int
+
+	maxOffsetSet bool
+	maxOffset    int
+
+	requireTimezone  bool
+	noYearZero       bool
+	collapse         bool
+	basicFormat      bool
+	caseless         bool
+	exactFraction    bool
+	listSep          rune
+	inputInErrors    int
+	inputInErrorsSet bool
+	strictLeap       bool
+	rounding         RoundingMode
+	clock            func() time.Time
+
+	layouts []string
+}
+
+var defaultDecoder Decoder
+
+// Option configures a Decoder.
+type Option func(*Decoder)
+
+// NewDecoder returns a Decoder configured with opts.
+func NewDecoder(opts ...Option) *Decoder {
+	d := &Decoder{}
+	for _, o := range opts {
+		o(d)
+	}
+	return d
+}
+
+// NewStrictXSD10 returns a Decoder for the dateTime of XSD 1.0, which has
+// no year 0000.
+func NewStrictXSD10() *Decoder {
+	return NewDecoder(WithoutYearZero())
+}
+
+// NewStrictXSD11 returns a Decoder for the dateTime of XSD 1.1, where year
+// 0000 is 1 BCE. It behaves like Parse.
+func NewStrictXSD11() *Decoder {
+	return NewDecoder()
+}
+
+// NewLenient returns a Decoder accepting common deviations from XSD:
+// surrounding whitespace, hour-only offsets and RFC 3339 like values with
+// a space instead of 'T', lowercase designators and the ISO 8601 basic
+// format.
+func NewLenient() *Decoder {
+	return NewDecoder(
+		WithWhitespaceCollapse(),
+		WithCaseInsensitiveDesignators(),
+		WithHourOnlyOffset(),
+		WithBasicFormat(),
+		WithFallbackLayouts("2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999"),
+	)
+}
+
+// ParseWith parses s using a Decoder configured with opts.
+func ParseWith(s string, opts ...Option) (time.Time, error) {
+	return NewDecoder(opts...).Parse(s)
+}
+
+// Parse parses s according to the Decoder options.
+func (d *Decoder) Parse(s string) (time.Time, error) {
+	f, err := d.parse(s)
+	if err != nil {
+		return not, err
+	}
+	return f.time(), nil
+}
+
+func (d *Decoder) parse(s string) (fields, error) {
+	in := s
+	if d.collapse {
+		s = collapse(s)
+	}
+	// before the designators and the basic format are rewritten
+	if d.clock != nil {
+		if f, ok := d.relative(s); ok {
+			return f, nil
+		}
+	}
+	if d.caseless {
+		s = strings.Map(upperDesignator, s)
+	}
+	if d.basicFormat {
+		s = extendBasic(s)
+	}
+	f, field, err := d.lexField(s)
+	if err != nil {
+		err = d.wrapErr(in, field, err)
+		if len(d.layouts) > 0 {
+			return d.fallback(s, err)
+		}
+		return f, err
+	}
+	if d.requireTimezone {
+		if err := f.checkTimezone(); err != nil {
+			return f, d.wrapErr(in, "timezone", err)
+		}
+	}
+	if d.noYearZero && f.year == 0 {
+		return f, d.wrapErr(in, "date", errors.New("year 0000 is not allowed"))
+	}
+	if d.strictLeap && f.second == 60 {
+		// minutes of the UTC day, zoneless values taken as UTC
+		if m := (f.hour*60 + f.minute - f.offset/60 + 2*24*60) % (24 * 60); m != 24*60-1 {
+			return f, d.wrapErr(in, "time", errors.New("leap second only allowed at 23:59:60 UTC"))
+		}
+	}
+	if d.exactFraction && f.digits > 9 {
+		return f, d.wrapErr(in, "time", fmt.Errorf("%w: fraction has %d digits, at most 9 are allowed", ErrFractionPrecision, f.digits))
+	}
+	if d.requirePrecision && f.digits != d.precision {
+		return f, d.wrapErr(in, "time", fmt.Errorf("fractional second must have exactly %d digits, got %d", d.precision, f.digits))
+	}
+	return f, nil
+}
+
+// ParseError is the error of the Decoder and Parse. It names the invalid
+// input and the part of it the error is about.
+type ParseError struct {
+	// Input is the value parsed, cut to 64 bytes or the WithInputInErrors
+	// limit.
+	Input string
+	// Field is "dateTime" for the value as a whole, or "date", "time" or
+	// "timezone".
+	Field string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s %q: %s", e.Field, e.Input, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// defaultInputInErrors is the input echoed in a ParseError by default, the
+// longest value the parser accepts.
+const defaultInputInErrors = maxLength
+
+// WithInputInErrors echoes at most max bytes of the input in a ParseError
+// instead of 64, so that logs of large batches are actionable without
+// dumping huge values. With max 0 no input is echoed.
+func WithInputInErrors(max int) Option {
+	if max < 0 {
+		max = 0
+	}
+	return func(d *Decoder) {
+		d.inputInErrorsSet = true
+		d.inputInErrors = max
+	}
+}
+
+func (d *Decoder) wrapErr(in, field string, err error) error {
+	limit := defaultInputInErrors
+	if d.inputInErrorsSet {
+		limit = d.inputInErrors
+	}
+	if len(in) > limit {
+		in = in[:limit] + "..."
+	}
+	// the input may be a view of a large buffer, do not retain it
+	return &ParseError{Input: strings.Clone(in), Field: field, Err: err}
+}
+
+// WithEpochScan makes Scan accept numeric sources (int64, float64 and
+// json.Number) as a Unix epoch counted in unit, e.g. time.Second or
+// time.Millisecond. Any unit is exact, and epochs beyond the range of
+// int64 seconds are rejected. Without it numeric sources are rejected.
+func WithEpochScan(unit time.Duration) Option {
+	return func(d *Decoder) {
+		d.epochUnit = unit
+	}
+}
+
+// WithRequiredPrecision requires the fractional second to have exactly
+// digits places, as schemas mandating e.g. millisecond precision do. With
+// digits 0 no fraction is allowed.
+func WithRequiredPrecision(digits int) Option {
+	return func(d *Decoder) {
+		d.requirePrecision = true
+		d.precision = digits
+	}
+}
+
+// WithHourOnlyOffset accepts a timezone of only the hour, ±hh, taking the
+// minutes as 00. Such offsets are not valid XSD.
+func WithHourOnlyOffset() Option {
+	return func(d *Decoder) {
+		d.hourOnlyOffset = true
+	}
+}
+
+// WithMaxOffsetHours sets the largest accepted timezone offset to ±n:00
+// instead of ±14:00, e.g. 12 for data from RFC 822 era tooling.
+func WithMaxOffsetHours(n int) Option {
+	return func(d *Decoder) {
+		d.maxOffsetSet = true
+		d.maxOffset = n
+	}
+}
+
+func (d *Decoder) maxOffsetHours() int {
+	if d.maxOffsetSet {
+		return d.maxOffset
+	}
+	return 14
+}
+
+func (d *Decoder) errOffsetRange() error {
+	return fmt.Errorf("max timezone hour is %d", d.maxOffsetHours())
+}
+
+func (d *Decoder) errOffsetMinutes() error {
+	return fmt.Errorf("timezone minute must be 00 when the hour is %d", d.maxOffsetHours())
+}
+
+// WithRequireTimezone rejects values without timezone, as
+// ParseDateTimeStamp does.
+func WithRequireTimezone() Option {
+	return func(d *Decoder) {
+		d.requireTimezone = true
+	}
+}
+
+// WithFallbackLayouts makes the Decoder try time.Parse with each of layouts
+// in order when s is not a valid dateTime. A value accepted this way skips
+// the other checks of the Decoder, and is zoneless when the layout has no
+// zone element.
+func WithFallbackLayouts(layouts ...string) Option {
+	return func(d *Decoder) {
+		d.layouts = append(d.layouts, layouts...)
+	}
+}
+
+func (d *Decoder) fallback(s string, err error) (fields, error) {
+	for _, l := range d.layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			f := fieldsOf(t)
+			if !layoutHasZone(l) {
+				f.zone = ZoneNone
+			}
+			return f, nil
+		}
+	}
+	return fields{}, fmt.Errorf("%w; fallback layouts %q did not match either", err, d.layouts)
+}
+
+// layoutHasZone reports whether the time.Parse layout l has a zone
+// element, e.g. MST, Z07:00 or -0700.
+func layoutHasZone(l string) bool {
+	return strings.Contains(l, "MST") || strings.Contains(l, "Z07") || strings.Contains(l, "-07")
+}
+
+// WithRelativeKeywords also accepts the keywords now, the time of clock,
+// and today, the start of its day in its location. They are not XSD and
+// skip the other checks of the Decoder. A nil clock means time.Now.
+func WithRelativeKeywords(clock func() time.Time) Option {
+	if clock == nil {
+		clock = time.Now
+	}
+	return func(d *Decoder) {
+		d.clock = clock
+	}
+}
+
+func (d *Decoder) relative(s string) (fields, bool) {
+	switch s {
+	case "now":
+		return fieldsOf(d.clock()), true
+	case "today":
+		t := d.clock()
+		y, m, day := t.Date()
+		return fieldsOf(time.Date(y, m, day, 0, 0, 0, 0, t.Location())), true
+	}
+	return fields{}, false
+}
+
+// WithoutYearZero rejects year 0000, which XSD 1.0 does not allow.
+func WithoutYearZero() Option {
+	return func(d *Decoder) {
+		d.noYearZero = true
+	}
+}
+
+// WithWhitespaceCollapse trims XML whitespace around the value before
+// parsing, as the whitespace facet of dateTime does in a schema.
+func WithWhitespaceCollapse() Option {
+	return func(d *Decoder) {
+		d.collapse = true
+	}
+}
+
+// WithStrictLeapSecond only accepts second 60 in the last minute of a UTC
+// day, 23:59:60Z or the same instant at an offset, where leap seconds are
+// inserted. By default :60 is accepted anywhere and moves to the next
+// minute.
+func WithStrictLeapSecond() Option {
+	return func(d *Decoder) {
+		d.strictLeap = true
+	}
+}
+
+// ErrFractionPrecision is returned under WithExactFraction for a fraction
+// of more than nine digits.
+var ErrFractionPrecision = errors.New("precision exceeds nanoseconds")
+
+// WithExactFraction rejects fractions of more than nine digits with
+// ErrFractionPrecision instead of rounding them to the nanosecond.
+func WithExactFraction() Option {
+	return func(d *Decoder) {
+		d.exactFraction = true
+	}
+}
+
+// RoundingMode selects how a fraction of more than nine digits is reduced
+// to nanoseconds.
+type RoundingMode uint8
+
+const (
+	// RoundHalfUp rounds a tenth digit of 5 or more up.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest nanosecond, and an exact half to
+	// the even one.
+	RoundHalfEven
+	// RoundDown drops the digits beyond the nanosecond.
+	RoundDown
+)
+
+// WithRoundingMode sets how a fraction of more than nine digits is reduced
+// to nanoseconds, RoundHalfUp by default.
+func WithRoundingMode(mode RoundingMode) Option {
+	return func(d *Decoder) {
+		d.rounding = mode
+	}
+}
+
+// WithCaseInsensitiveDesignators also accepts the 't' separator and the
+// 'z' designator in lowercase, e.g. 2017-08-16t13:07:00z. Output always
+// uses uppercase.
+func WithCaseInsensitiveDesignators() Option {
+	return func(d *Decoder) {
+		d.caseless = true
+	}
+}
+
+// WithListSeparator makes Decoder.ParseList split on r instead of XML
+// whitespace, e.g. ',' for comma separated values. Whitespace around the
+// items is trimmed.
+func WithListSeparator(r rune) Option {
+	return func(d *Decoder) {
+		d.listSep = r
+	}
+}
+
+// WithBasicFormat also accepts the ISO 8601 basic format without
+// separators, e.g. 20170816T130700Z or 20170816T130700.5+0200, which is
+// rewritten to the extended form before parsing.
+func WithBasicFormat() Option {
+	return func(d *Decoder) {
+		d.basicFormat = true
+	}
+}
+
+// extendBasic returns s in the extended format when it is in the basic
+// format, and s unchanged otherwise.
+func extendBasic(s string) string {
+	t := strings.IndexByte(s, 'T')
+	if t < 0 {
+		return s
+	}
+	date, rest := s[:t], s[t+1:]
+	sign := ""
+	if len(date) > 0 && date[0] == '-' {
+		sign, date = "-", date[1:]
+	}
+	if len(date) != 8 || !allDigits(date) || len(rest) < 6 || !allDigits(rest[:6]) {
+		return s
+	}
+	b := make([]byte, 0, len(s)+5)
+	b = append(b, sign...)
+	b = append(b, date[:4]...)
+	b = append(b, '-')
+	b = append(b, date[4:6]...)
+	b = append(b, '-')
+	b = append(b, date[6:]...)
+	b = append(b, 'T')
+	b = append(b, rest[:2]...)
+	b = append(b, ':')
+	b = append(b, rest[2:4]...)
+	b = append(b, ':')
+	b = append(b, rest[4:6]...)
+	rest = rest[6:]
+	if len(rest) > 0 && rest[0] == '.' {
+		i := 1
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		b = append(b, rest[:i]...)
+		rest = rest[i:]
+	}
+	if len(rest) == 5 && (rest[0] == '+' || rest[0] == '-') && allDigits(rest[1:]) {
+		b = append(b, rest[:3]...)
+		b = append(b, ':')
+		b = append(b, rest[3:]...)
+		return string(b)
+	}
+	return string(append(b, rest...))
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,431 @@
+package xmldatetime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithRequiredPrecision(t *testing.T) {
+	d := NewDecoder(WithRequiredPrecision(3))
+	for _, v := range []struct {
+		in string
+		ok bool
+	}{
+		{"2017-08-16T13:07:00.092Z", true},
+		{"2017-08-16T13:07:00.090Z", true},
+		{"2017-08-16T13:07:00.09Z", false},
+		{"2017-08-16T13:07:00.0925Z", false},
+		{"2017-08-16T13:07:00Z", false},
+	} {
+		_, err := d.Parse(v.in)
+		if (err == nil) != v.ok {
+			t.Errorf("%s: want ok: %v, got: %v", v.in, v.ok, err)
+		}
+		if _, err := Parse(v.in); err != nil {
+			t.Errorf("Parse(%s): %s", v.in, err)
+		}
+	}
+	if _, err := ParseWith("2017-08-16T13:07:00Z", WithRequiredPrecision(0)); err != nil {
+		t.Errorf("want no fraction accepted, got: %s", err)
+	}
+}
+
+func TestWithHourOnlyOffset(t *testing.T) {
+	d := NewDecoder(WithHourOnlyOffset())
+	for _, v := range []struct {
+		in     string
+		offset int
+	}{
+		{"2017-08-16T13:07:00+02", 2 * 60 * 60},
+		{"2017-08-16T06:07:00-05", -5 * 60 * 60},
+		{"2017-08-16T13:07:00+02:00", 2 * 60 * 60},
+	} {
+		tm, err := d.Parse(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if _, offset := tm.Zone(); offset != v.offset {
+			t.Errorf("%s: want offset: %d, got: %d", v.in, v.offset, offset)
+		}
+		if ex := time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC); !tm.Equal(ex) {
+			t.Errorf("%s: want: %s, got: %s", v.in, ex, tm)
+		}
+	}
+	for _, v := range []string{"2017-08-16T13:07:00+15", "2017-08-16T13:07:00*02", "2017-08-16T13:07:00+2x"} {
+		if _, err := d.Parse(v); err == nil {
+			t.Errorf("want error, got nil: %s", v)
+		}
+	}
+	for _, v := range []string{"2017-08-16T13:07:00+02", "2017-08-16T06:07:00-05"} {
+		if _, err := Parse(v); err == nil {
+			t.Errorf("Parse: want error, got nil: %s", v)
+		}
+	}
+}
+
+func TestWithMaxOffsetHours(t *testing.T) {
+	for _, v := range []struct {
+		d  *Decoder
+		in string
+		ok bool
+	}{
+		{&defaultDecoder, "2017-08-16T13:07:00+14:00", true},
+		{&defaultDecoder, "2017-08-16T13:07:00-14:00", true},
+		{&defaultDecoder, "2017-08-16T13:07:00+14:30", false},
+		{NewDecoder(WithMaxOffsetHours(12)), "2017-08-16T13:07:00+12:00", true},
+		{NewDecoder(WithMaxOffsetHours(12)), "2017-08-16T13:07:00-12:00", true},
+		{NewDecoder(WithMaxOffsetHours(12)), "2017-08-16T13:07:00+12:45", false},
+		{NewDecoder(WithMaxOffsetHours(12)), "2017-08-16T13:07:00+14:00", false},
+		{NewDecoder(WithMaxOffsetHours(12), WithHourOnlyOffset()), "2017-08-16T13:07:00-13", false},
+	} {
+		_, err := v.d.Parse(v.in)
+		if (err == nil) != v.ok {
+			t.Errorf("%s: want ok: %v, got: %v", v.in, v.ok, err)
+		}
+	}
+}
+
+func TestWithRequireTimezone(t *testing.T) {
+	for _, v := range []struct {
+		in string
+		ok bool
+	}{
+		{"2017-08-16T13:07:00Z", true},
+		{"2017-08-16T13:07:00+02:00", true},
+		{"2017-08-16T13:07:00", false},
+	} {
+		if _, err := ParseWith(v.in, WithRequireTimezone()); (err == nil) != v.ok {
+			t.Errorf("%s: want ok: %v, got: %v", v.in, v.ok, err)
+		}
+		if _, err := ParseDateTimeStamp(v.in); (err == nil) != v.ok {
+			t.Errorf("ParseDateTimeStamp(%s): want ok: %v, got: %v", v.in, v.ok, err)
+		}
+		if _, err := Parse(v.in); err != nil {
+			t.Errorf("Parse(%s): %s", v.in, err)
+		}
+	}
+}
+
+func TestWithFallbackLayouts(t *testing.T) {
+	d := NewDecoder(WithFallbackLayouts(time.RFC1123Z, "2006-01-02 15:04:05"))
+	for _, v := range []string{
+		"2017-08-16T13:07:00+02:00",
+		"Wed, 16 Aug 2017 13:07:00 +0200",
+		"2017-08-16 11:07:00",
+	} {
+		tm, err := d.Parse(v)
+		if err != nil {
+			t.Errorf("%s: %s", v, err)
+			continue
+		}
+		if ex := time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC); !tm.Equal(ex) {
+			t.Errorf("%s: want: %s, got: %s", v, ex, tm)
+		}
+		if _, err := Parse(v); err == nil && strings.Contains(v, " ") {
+			t.Errorf("Parse(%s): want error, got nil", v)
+		}
+	}
+	_, err := d.Parse("16/08/2017")
+	if err == nil || !strings.Contains(err.Error(), time.RFC1123Z) {
+		t.Errorf("want error naming the layouts, got: %v", err)
+	}
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-08-16 13:07:00", "2017-08-16T13:07:00"},
+		{"2017-08-16 13:07:00Z", "2017-08-16T13:07:00Z"},
+		{"2017-08-16 13:07:00+02:00", "2017-08-16T13:07:00+02:00"},
+	} {
+		var c CustomTime
+		if err := NewLenient().Scanner(&c).Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got, _ := c.Value(); got != v.want {
+			t.Errorf("%s: want: %s, got: %v", v.in, v.want, got)
+		}
+	}
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Field != "date" {
+		t.Errorf("want *ParseError of date, got: %v", err)
+	}
+	_, err = NewDecoder(WithFallbackLayouts(time.RFC1123Z)).Parse("2017-08-16T13:07:00.Z")
+	if !errors.Is(err, ErrFractionDigits) {
+		t.Errorf("want wrapped ErrFractionDigits, got: %v", err)
+	}
+}
+
+func TestPresets(t *testing.T) {
+	for _, v := range []struct {
+		in                    string
+		xsd10, xsd11, lenient bool
+	}{
+		{"2017-08-16T13:07:00Z", true, true, true},
+		{"0000-08-16T13:07:00Z", false, true, true},
+		{"-0000-08-16T13:07:00Z", false, true, true},
+		{" 2017-08-16T13:07:00Z\n", false, false, true},
+		{"2017-08-16T13:07:00+02", false, false, true},
+		{"2017-08-16 13:07:00.5+02:00", false, false, true},
+		{"20170816T130700Z", false, false, true},
+		{"2017-08-16t13:07:00z", false, false, true},
+		{"2017-08-16T13:07:00+15:00", false, false, false},
+	} {
+		for _, d := range []struct {
+			name string
+			d    *Decoder
+			ok   bool
+		}{
+			{"XSD10", NewStrictXSD10(), v.xsd10},
+			{"XSD11", NewStrictXSD11(), v.xsd11},
+			{"Lenient", NewLenient(), v.lenient},
+		} {
+			if _, err := d.d.Parse(v.in); (err == nil) != d.ok {
+				t.Errorf("%s %q: want ok: %v, got: %v", d.name, v.in, d.ok, err)
+			}
+		}
+	}
+}
+
+func TestWithBasicFormat(t *testing.T) {
+	d := NewDecoder(WithBasicFormat())
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"20170816T130700Z", "2017-08-16T13:07:00Z"},
+		{"20170816T130700.5+0200", "2017-08-16T13:07:00.5+02:00"},
+		{"20170816T130700-05:30", "2017-08-16T13:07:00-05:30"},
+		{"20170816T130700", "2017-08-16T13:07:00Z"},
+		{"-00440315T120000Z", "-0044-03-15T12:00:00Z"},
+		{"2017-08-16T13:07:00Z", "2017-08-16T13:07:00Z"},
+	} {
+		tm, err := d.Parse(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := Format(tm); got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+	}
+	for _, v := range []string{"20170816T1307Z", "2017081T130700Z", "20170230T130700Z", "20170816T130700+020", "2017-0816T130700Z", "120170816T130700Z"} {
+		if _, err := d.Parse(v); err == nil {
+			t.Errorf("want error, got nil: %s", v)
+		}
+	}
+	if _, err := Parse("20170816T130700Z"); err == nil {
+		t.Errorf("Parse: want error, got nil")
+	}
+}
+
+func TestWithCaseInsensitiveDesignators(t *testing.T) {
+	d := NewDecoder(WithCaseInsensitiveDesignators())
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-08-16t13:07:00z", "2017-08-16T13:07:00Z"},
+		{"2017-08-16t13:07:00.5Z", "2017-08-16T13:07:00.5Z"},
+		{"2017-08-16T13:07:00z", "2017-08-16T13:07:00Z"},
+		{"2017-08-16t13:07:00+02:00", "2017-08-16T13:07:00+02:00"},
+		{"2017-08-16t13:07:00", "2017-08-16T13:07:00"},
+	} {
+		tm, err := d.Parse(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		var c CustomTime
+		if err := d.Scanner(&c).Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		got, _ := c.Value()
+		if got != v.want {
+			t.Errorf("%s: want: %s, got: %v", v.in, v.want, got)
+		}
+		if !c.Time.Equal(tm) {
+			t.Errorf("%s: want: %s, got: %s", v.in, tm, c.Time)
+		}
+		if _, err := Parse(v.in); err == nil && v.in != v.want {
+			t.Errorf("Parse: want error, got nil: %s", v.in)
+		}
+	}
+	if s, err := CanonicalString("2017-08-16t13:07:00z"); err != nil || s != "2017-08-16T13:07:00Z" {
+		t.Errorf("want canonical 2017-08-16T13:07:00Z, got: %s, %v", s, err)
+	}
+}
+
+func TestWithExactFraction(t *testing.T) {
+	d := NewDecoder(WithExactFraction())
+	for _, v := range []string{"2017-08-16T13:07:00Z", "2017-08-16T13:07:00.5Z", "2017-08-16T13:07:00.123456789Z"} {
+		if _, err := d.Parse(v); err != nil {
+			t.Errorf("%s: %s", v, err)
+		}
+	}
+	const in = "2017-08-16T13:07:00.123456789999Z"
+	_, err := d.Parse(in)
+	if !errors.Is(err, ErrFractionPrecision) {
+		t.Errorf("want ErrFractionPrecision, got: %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "12 digits") {
+		t.Errorf("want digit count 12 in error, got: %v", err)
+	}
+	tm, err := Parse(in)
+	if err != nil {
+		t.Errorf("Parse: %s", err)
+		t.FailNow()
+	}
+	if tm.Nanosecond() != 123456790 {
+		t.Errorf("Parse: want rounded 123456790ns, got: %d", tm.Nanosecond())
+	}
+}
+
+func TestWithRoundingMode(t *testing.T) {
+	tests := []struct {
+		frac string
+		mode RoundingMode
+		nsec int
+	}{
+		{"1234567885", RoundHalfUp, 123456789},
+		{"1234567895", RoundHalfUp, 123456790},
+		{"1234567894", RoundHalfUp, 123456789},
+		{"1234567885", RoundHalfEven, 123456788},
+		{"1234567895", RoundHalfEven, 123456790},
+		{"12345678850001", RoundHalfEven, 123456789},
+		{"12345678849", RoundHalfEven, 123456788},
+		{"1234567885", RoundDown, 123456788},
+		{"1234567899", RoundDown, 123456789},
+		{"9999999985", RoundHalfEven, 999999998},
+		{"9999999999", RoundDown, 999999999},
+	}
+	for _, tt := range tests {
+		tm, err := ParseWith("2017-08-16T13:07:00."+tt.frac+"Z", WithRoundingMode(tt.mode))
+		if err != nil {
+			t.Errorf("%s: %s", tt.frac, err)
+			continue
+		}
+		if tm.Nanosecond() != tt.nsec {
+			t.Errorf("%s mode %d: want %dns, got: %d", tt.frac, tt.mode, tt.nsec, tm.Nanosecond())
+		}
+	}
+}
+
+func TestWithInputInErrors(t *testing.T) {
+	d := NewDecoder(WithInputInErrors(32))
+	for _, v := range []struct {
+		in, field, want string
+	}{
+		{"2017-08-16T13:07", "time", `time "2017-08-16T13:07": expected : in dateTime format after 2 digit minute`},
+		{"2017-02-30T13:07:00Z", "date", `date "2017-02-30T13:07:00Z": day out of range for month`},
+		{"2017-08-16T13:07:00+15:00", "timezone", `timezone "2017-08-16T13:07:00+15:00": max timezone hour is 14`},
+		{"", "dateTime", `dateTime "": empty dateTime`},
+		{"2017-08-16T13:07:00.123456789012345678901234+99:00", "timezone", ""},
+	} {
+		_, err := d.Parse(v.in)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%q: want *ParseError, got: %v", v.in, err)
+			continue
+		}
+		if pe.Field != v.field {
+			t.Errorf("%q: want field: %s, got: %s", v.in, v.field, pe.Field)
+		}
+		if v.want != "" && err.Error() != v.want {
+			t.Errorf("%q: want: %s, got: %s", v.in, v.want, err)
+		}
+		if !strings.Contains(err.Error(), strings.TrimSuffix(pe.Input, "...")) {
+			t.Errorf("%q: want input in error, got: %s", v.in, err)
+		}
+	}
+
+	_, err := d.Parse("2017-08-16T13:07:00.123456789012345678901234567890123456789+99:00")
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Input != "2017-08-16T13:07:00.123456789012..." {
+		t.Errorf("want input cut to 32 bytes, got: %v", err)
+	}
+	_, err = NewDecoder(WithInputInErrors(64), WithExactFraction()).Parse("2017-08-16T13:07:00.1234567890Z")
+	if !errors.Is(err, ErrFractionPrecision) || !strings.Contains(err.Error(), "2017-08-16T13:07:00.1234567890Z") {
+		t.Errorf("want wrapped ErrFractionPrecision with input, got: %v", err)
+	}
+	_, err = NewDecoder(WithInputInErrors(64)).Parse("2017-08-16T13:07:00.")
+	if !errors.Is(err, ErrFractionDigits) {
+		t.Errorf("want wrapped ErrFractionDigits, got: %v", err)
+	}
+	_, err = Parse("2017-08-16T13:07")
+	if want := `time "2017-08-16T13:07": expected : in dateTime format after 2 digit minute`; err == nil || err.Error() != want {
+		t.Errorf("Parse: want: %s, got: %v", want, err)
+	}
+	long := "2017-08-16T13:07:00." + strings.Repeat("1", 100) + "Z"
+	if _, err := Parse(long); !errors.As(err, &pe) || pe.Input != long[:64]+"..." || pe.Field != "dateTime" {
+		t.Errorf("Parse: want input cut to 64 bytes, got: %v", err)
+	}
+	_, err = NewDecoder(WithInputInErrors(0)).Parse("2017-08-16T13:07")
+	if !errors.As(err, &pe) || pe.Input != "..." || pe.Field != "time" {
+		t.Errorf("want no input, got: %v", err)
+	}
+}
+
+func TestWithStrictLeapSecond(t *testing.T) {
+	d := NewDecoder(WithStrictLeapSecond())
+	for _, v := range []struct {
+		in     string
+		strict bool
+	}{
+		{"2016-12-31T23:59:60Z", true},
+		{"2016-12-31T23:59:60.5Z", true},
+		{"2017-01-01T01:59:60+02:00", true},
+		{"2016-12-31T18:29:60-05:30", true},
+		{"2016-12-31T23:59:60", true},
+		{"2017-08-16T13:07:60Z", false},
+		{"2016-12-31T23:59:60+02:00", false},
+		{"2016-12-31T23:58:60Z", false},
+	} {
+		if _, err := d.Parse(v.in); (err == nil) != v.strict {
+			t.Errorf("%s: want strict ok: %v, got: %v", v.in, v.strict, err)
+		}
+		if _, err := Parse(v.in); err != nil {
+			t.Errorf("Parse(%s): %s", v.in, err)
+		}
+	}
+	tm, err := Parse("2017-08-16T13:07:60Z")
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if want := time.Date(2017, time.August, 16, 13, 8, 0, 0, time.UTC); !tm.Equal(want) {
+		t.Errorf("want: %s, got: %s", want, tm)
+	}
+}
+
+func TestWithRelativeKeywords(t *testing.T) {
+	now := time.Date(2017, time.August, 16, 13, 7, 30, 5e8, time.FixedZone("", -4*3600))
+	clock := func() time.Time { return now }
+	tm, err := ParseWith("now", WithRelativeKeywords(clock))
+	if err != nil {
+		t.Errorf("now: %s", err)
+	} else if !tm.Equal(now) {
+		t.Errorf("now: want: %s, got: %s", now, tm)
+	}
+	tm, err = ParseWith("today", WithRelativeKeywords(clock))
+	if err != nil {
+		t.Errorf("today: %s", err)
+	} else if want := "2017-08-16T00:00:00-04:00"; Format(tm) != want {
+		t.Errorf("today: want: %s, got: %s", want, Format(tm))
+	}
+	if _, err := ParseWith("2017-08-16T13:07:00Z", WithRelativeKeywords(clock)); err != nil {
+		t.Errorf("dateTime: %s", err)
+	}
+	d := NewDecoder(WithRelativeKeywords(clock), WithWhitespaceCollapse(), WithCaseInsensitiveDesignators(), WithBasicFormat())
+	for _, v := range []string{"now", " today\n"} {
+		if _, err := d.Parse(v); err != nil {
+			t.Errorf("%q with rewriting options: %s", v, err)
+		}
+	}
+	for _, v := range []string{"now", "today"} {
+		if _, err := Parse(v); err == nil {
+			t.Errorf("Parse(%s): want error", v)
+		}
+	}
+}
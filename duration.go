@@ -0,0 +1,312 @@
+package xmldatetime
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is an xs:duration value. The components are kept as written,
+// since months and days have no fixed length.
+type Duration struct {
+	Negative                                     bool
+	Years, Months, Days, Hours, Minutes, Seconds int
+	Nanoseconds                                  int
+}
+
+// ParseDuration parses the xs:duration lexical representation
+// '-'? 'P' (nY)? (nM)? (nD)? ('T' (nH)? (nM)? (n('.' n+)?S)?)?
+// At least one component is required and 'T' must be followed by one.
+func ParseDuration(s string) (Duration, error) {
+	var d Duration
+	if len(s) > 0 && s[0] == '-' {
+		d.Negative = true
+		s = s[1:]
+	}
+	if len(s) == 0 || s[0] != 'P' {
+		return d, errors.New("duration must start with P")
+	}
+	s = s[1:]
+	if len(s) == 0 {
+		return d, errors.New("duration requires at least one component")
+	}
+	// designators in the order they may appear, T splits date and time
+	const order = "YMDTHMS"
+	pos := 0
+	timePart := false
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if timePart {
+				return d, errors.New("duplicate T in duration")
+			}
+			timePart = true
+			pos = strings.IndexByte(order, 'T') + 1
+			s = s[1:]
+			if len(s) == 0 {
+				return d, errors.New("T in duration must be followed by a time component")
+			}
+			continue
+		}
+		n, rest, err := durationInt(s)
+		if err != nil {
+			return d, err
+		}
+		s = rest
+		nsec := 0
+		if len(s) > 0 && s[0] == '.' {
+			if !timePart {
+				return d, errors.New("only seconds may have a fraction")
+			}
+			nsec, s, err = parseFractionalSecond(s[1:])
+			if err != nil {
+				return d, err
+			}
+			if len(s) == 0 || s[0] != 'S' {
+				return d, errors.New("only seconds may have a fraction")
+			}
+		}
+		if len(s) == 0 {
+			return d, errors.New("missing designator after number in duration")
+		}
+		i := strings.IndexByte(order[pos:], s[0])
+		if i < 0 || (!timePart && pos+i > 2) || (timePart && pos+i < 4) {
+			return d, errors.New("unexpected designator " + strconv.Quote(s[:1]) + " in duration")
+		}
+		pos += i
+		switch pos {
+		case 0:
+			d.Years = n
+		case 1:
+			d.Months = n
+		case 2:
+			d.Days = n
+		case 4:
+			d.Hours = n
+		case 5:
+			d.Minutes = n
+		case 6:
+			d.Seconds, d.Nanoseconds = n, nsec
+			if d.Nanoseconds == 1e9 {
+				d.Seconds, d.Nanoseconds = d.Seconds+1, 0
+			}
+		}
+		pos++
+		s = s[1:]
+	}
+	return d, nil
+}
+
+// durationInt reads the unsigned integer at the start of s.
+func durationInt(s string) (int, string, error) {
+	i := 0
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return 0, s, errors.New("expected number in duration")
+	}
+	n, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return 0, s, errors.New("duration component out of range")
+	}
+	return n, s[i:], nil
+}
+
+// String returns d in the xs:duration lexical form, omitting zero
+// components; a zero duration is PT0S.
+func (d Duration) String() string {
+	return string(AppendDuration(make([]byte, 0, 32), d))
+}
+
+// AppendDuration appends the lexical form of d, as returned by String, to
+// dst.
+func AppendDuration(dst []byte, d Duration) []byte {
+	if d.Negative {
+		dst = append(dst, '-')
+	}
+	dst = append(dst, 'P')
+	hasTime := d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 || d.Nanoseconds != 0
+	if !hasTime && d.Years == 0 && d.Months == 0 && d.Days == 0 {
+		return append(dst[:len(dst)-1], "PT0S"...)
+	}
+	dst = appendComponent(dst, d.Years, 'Y')
+	dst = appendComponent(dst, d.Months, 'M')
+	dst = appendComponent(dst, d.Days, 'D')
+	if !hasTime {
+		return dst
+	}
+	dst = append(dst, 'T')
+	dst = appendComponent(dst, d.Hours, 'H')
+	dst = appendComponent(dst, d.Minutes, 'M')
+	if d.Seconds != 0 || d.Nanoseconds != 0 {
+		dst = strconv.AppendInt(dst, int64(d.Seconds), 10)
+		dst = appendFraction(dst, d.Nanoseconds)
+		dst = append(dst, 'S')
+	}
+	return dst
+}
+
+// Between returns the calendar duration from a to b: the most whole months
+// that can be added to a without passing b, as the addition of an
+// xs:duration does with the day clamped to the month's end, followed by
+// the remaining days and time. So 2016-06-16 to 2017-08-16 is P1Y2M and
+// 2017-01-31 to 2017-03-01 is P1M1D. b is taken in the location of a. The
+// duration is negative when b is before a.
+func Between(a, b time.Time) Duration {
+	var d Duration
+	b = b.In(a.Location())
+	if b.Before(a) {
+		a, b = b, a
+		d.Negative = true
+	}
+	months := (b.Year()-a.Year())*12 + int(b.Month()-a.Month())
+	c := addMonths(a, months)
+	if c.After(b) {
+		months--
+		c = addMonths(a, months)
+	}
+	d.Years, d.Months = months/12, months%12
+
+	y1, m1, d1 := c.Date()
+	y2, m2, d2 := b.Date()
+	d.Days = int(time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC).Sub(time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+	h1, mi1, s1 := c.Clock()
+	h2, mi2, s2 := b.Clock()
+	d.Hours, d.Minutes, d.Seconds = h2-h1, mi2-mi1, s2-s1
+	d.Nanoseconds = b.Nanosecond() - c.Nanosecond()
+	if d.Nanoseconds < 0 {
+		d.Nanoseconds += 1e9
+		d.Seconds--
+	}
+	if d.Seconds < 0 {
+		d.Seconds += 60
+		d.Minutes--
+	}
+	if d.Minutes < 0 {
+		d.Minutes += 60
+		d.Hours--
+	}
+	if d.Hours < 0 {
+		d.Hours += 24
+		d.Days--
+	}
+	return d
+}
+
+// addMonths adds n months to t, clamping the day to the end of the month.
+func addMonths(t time.Time, n int) time.Time {
+	m := int(t.Month()) - 1 + n
+	y := t.Year() + m/12
+	if m %= 12; m < 0 {
+		m += 12
+		y--
+	}
+	month := time.Month(m + 1)
+	day := t.Day()
+	if max := daysIn(month, y); day > max {
+		day = max
+	}
+	return time.Date(y, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// AgeAsOf returns the calendar duration from c to ref, see Between.
+func (c CustomTime) AgeAsOf(ref time.Time) Duration {
+	return Between(c.Time, ref)
+}
+
+// ToISO8601 returns d in the ISO 8601 duration form, which is the same as
+// String for a positive duration. ISO 8601 has no negative durations, so
+// a negative d is an error where String writes a leading '-'.
+func (d Duration) ToISO8601() (string, error) {
+	if d.Negative {
+		return "", errors.New("ISO 8601 does not allow negative durations")
+	}
+	return d.String(), nil
+}
+
+// FormatDurationVerbose returns d with every component, zeros included,
+// e.g. P0Y1M0DT0H0M1.5S, for comparing durations field by field. String
+// returns the canonical form, which omits zero components.
+func FormatDurationVerbose(d Duration) string {
+	b := make([]byte, 0, 32)
+	if d.Negative {
+		b = append(b, '-')
+	}
+	b = append(b, 'P')
+	b = append(strconv.AppendInt(b, int64(d.Years), 10), 'Y')
+	b = append(strconv.AppendInt(b, int64(d.Months), 10), 'M')
+	b = append(strconv.AppendInt(b, int64(d.Days), 10), 'D', 'T')
+	b = append(strconv.AppendInt(b, int64(d.Hours), 10), 'H')
+	b = append(strconv.AppendInt(b, int64(d.Minutes), 10), 'M')
+	b = appendFraction(strconv.AppendInt(b, int64(d.Seconds), 10), d.Nanoseconds)
+	return string(append(b, 'S'))
+}
+
+func appendComponent(dst []byte, v int, designator byte) []byte {
+	if v == 0 {
+		return dst
+	}
+	return append(strconv.AppendInt(dst, int64(v), 10), designator)
+}
+
+// appendFraction appends '.' and nsec without trailing zeros, nothing for
+// zero.
+func appendFraction(dst []byte, nsec int) []byte {
+	if nsec == 0 {
+		return dst
+	}
+	width := 9
+	for nsec%10 == 0 {
+		nsec /= 10
+		width--
+	}
+	return appendInt(append(dst, '.'), nsec, width)
+}
+
+// Humanize returns d in a form for display only, e.g.
+// "1 year 2 months 3 days 4h5m6s"; it is not accepted by ParseDuration.
+func (d Duration) Humanize() string {
+	var b []byte
+	if d.Negative {
+		b = append(b, '-')
+	}
+	for _, c := range []struct {
+		v    int
+		unit string
+	}{{d.Years, "year"}, {d.Months, "month"}, {d.Days, "day"}} {
+		if c.v == 0 {
+			continue
+		}
+		if len(b) > 0 && b[len(b)-1] != '-' {
+			b = append(b, ' ')
+		}
+		b = strconv.AppendInt(b, int64(c.v), 10)
+		b = append(b, ' ')
+		b = append(b, c.unit...)
+		if c.v != 1 {
+			b = append(b, 's')
+		}
+	}
+	hasDate := len(b) > 0 && b[len(b)-1] != '-'
+	if d.Hours == 0 && d.Minutes == 0 && d.Seconds == 0 && d.Nanoseconds == 0 {
+		if hasDate {
+			return string(b)
+		}
+		return "0s"
+	}
+	if hasDate {
+		b = append(b, ' ')
+	}
+	if d.Hours != 0 {
+		b = append(strconv.AppendInt(b, int64(d.Hours), 10), 'h')
+	}
+	if d.Minutes != 0 {
+		b = append(strconv.AppendInt(b, int64(d.Minutes), 10), 'm')
+	}
+	if d.Seconds != 0 || d.Nanoseconds != 0 {
+		b = strconv.AppendInt(b, int64(d.Seconds), 10)
+		b = append(appendFraction(b, d.Nanoseconds), 's')
+	}
+	return string(b)
+}
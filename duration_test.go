@@ -0,0 +1,259 @@
+package xmldatetime
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseDuration(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want Duration
+		str  string
+	}{
+		{"P1Y2M3DT4H5M6S", Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}, "P1Y2M3DT4H5M6S"},
+		{"-P10D", Duration{Negative: true, Days: 10}, "-P10D"},
+		{"PT0.5S", Duration{Nanoseconds: 5e8}, "PT0.5S"},
+		{"PT1.250S", Duration{Seconds: 1, Nanoseconds: 25e7}, "PT1.25S"},
+		{"P0Y", Duration{}, "PT0S"},
+		{"P1M", Duration{Months: 1}, "P1M"},
+		{"PT1M", Duration{Minutes: 1}, "PT1M"},
+	} {
+		d, err := ParseDuration(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if d != v.want {
+			t.Errorf("%s: want: %+v, got: %+v", v.in, v.want, d)
+		}
+		if s := d.String(); s != v.str {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.str, s)
+		}
+	}
+	for _, v := range []string{
+		"", "P", "-P", "1Y", "PT", "P1YT", "P1S", "PT1D", "P1D1Y", "P1.5Y", "PT1.S", "P-1Y", "P1Y2", "PTT1H",
+	} {
+		if _, err := ParseDuration(v); err == nil {
+			t.Errorf("want error, got nil: %q", v)
+		}
+	}
+}
+
+func TestParseDurationTimeDesignator(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want Duration
+	}{
+		{"PT5M", Duration{Minutes: 5}},
+		{"PT0.5S", Duration{Nanoseconds: 5e8}},
+		{"P1Y", Duration{Years: 1}},
+		{"P1YT2H", Duration{Years: 1, Hours: 2}},
+		{"P5M", Duration{Months: 5}},
+	} {
+		d, err := ParseDuration(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if d != v.want {
+			t.Errorf("%s: want: %+v, got: %+v", v.in, v.want, d)
+		}
+	}
+	for _, v := range []string{"P1YT", "PT", "-PT", "P1DT"} {
+		_, err := ParseDuration(v)
+		if err == nil || err.Error() != "T in duration must be followed by a time component" {
+			t.Errorf("%s: want T error, got: %v", v, err)
+		}
+	}
+	for _, v := range []string{"P1H", "P5S", "PT1Y", "P1DT2D"} {
+		if _, err := ParseDuration(v); err == nil {
+			t.Errorf("%s: want error, got nil", v)
+		}
+	}
+}
+
+func TestAppendDuration(t *testing.T) {
+	d := Duration{Negative: true, Years: 1, Hours: 4, Seconds: 6, Nanoseconds: 5e8}
+	if got := string(AppendDuration([]byte("x="), d)); got != "x=-P1YT4H6.5S" {
+		t.Errorf("want: x=-P1YT4H6.5S, got: %s", got)
+	}
+	if got := string(AppendDuration(nil, Duration{Negative: true})); got != "-PT0S" {
+		t.Errorf("want: -PT0S, got: %s", got)
+	}
+	if got := sprintfDuration(d); got != d.String() {
+		t.Errorf("want: %s, got: %s", got, d.String())
+	}
+}
+
+// sprintfDuration is the fmt based implementation AppendDuration is
+// benchmarked against.
+func sprintfDuration(d Duration) string {
+	s := ""
+	if d.Negative {
+		s = "-"
+	}
+	s += "P"
+	for _, c := range []struct {
+		v int
+		d string
+	}{{d.Years, "Y"}, {d.Months, "M"}, {d.Days, "D"}} {
+		if c.v != 0 {
+			s += fmt.Sprintf("%d%s", c.v, c.d)
+		}
+	}
+	if d.Hours == 0 && d.Minutes == 0 && d.Seconds == 0 && d.Nanoseconds == 0 {
+		if s == "P" || s == "-P" {
+			return s + "T0S"
+		}
+		return s
+	}
+	s += "T"
+	if d.Hours != 0 {
+		s += fmt.Sprintf("%dH", d.Hours)
+	}
+	if d.Minutes != 0 {
+		s += fmt.Sprintf("%dM", d.Minutes)
+	}
+	if d.Seconds != 0 || d.Nanoseconds != 0 {
+		s += fmt.Sprintf("%d", d.Seconds)
+		if d.Nanoseconds != 0 {
+			s += strings.TrimRight(fmt.Sprintf(".%09d", d.Nanoseconds), "0")
+		}
+		s += "S"
+	}
+	return s
+}
+
+func TestDuration_Humanize(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"P1Y2M3DT4H5M6S", "1 year 2 months 3 days 4h5m6s"},
+		{"P2Y1M1D", "2 years 1 month 1 day"},
+		{"P1D", "1 day"},
+		{"PT1H", "1h"},
+		{"-P1DT12H", "-1 day 12h"},
+		{"-PT1M30.5S", "-1m30.5s"},
+		{"PT0S", "0s"},
+		{"-P0D", "0s"},
+	} {
+		d, err := ParseDuration(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := d.Humanize(); got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+	}
+}
+
+func TestFormatDurationVerbose(t *testing.T) {
+	for _, v := range []struct {
+		in, canonical, verbose string
+	}{
+		{"PT0S", "PT0S", "P0Y0M0DT0H0M0S"},
+		{"P1M", "P1M", "P0Y1M0DT0H0M0S"},
+		{"P1DT2.5S", "P1DT2.5S", "P0Y0M1DT0H0M2.5S"},
+		{"-PT1M30S", "-PT1M30S", "-P0Y0M0DT0H1M30S"},
+		{"P1Y2M3DT4H5M6.07S", "P1Y2M3DT4H5M6.07S", "P1Y2M3DT4H5M6.07S"},
+	} {
+		d, err := ParseDuration(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := d.String(); got != v.canonical {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.canonical, got)
+		}
+		got := FormatDurationVerbose(d)
+		if got != v.verbose {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.verbose, got)
+		}
+		if back, err := ParseDuration(got); err != nil || back != d {
+			t.Errorf("%s: round trip: want: %v, got: %v, %v", v.in, d, back, err)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	for _, v := range []struct {
+		a, b, want string
+	}{
+		{"2016-06-16T13:07:00Z", "2017-08-16T13:07:00Z", "P1Y2M"},
+		{"2017-08-16T13:07:00Z", "2016-06-16T13:07:00Z", "-P1Y2M"},
+		{"2017-01-31T00:00:00Z", "2017-03-01T00:00:00Z", "P1M1D"},
+		{"2016-02-29T00:00:00Z", "2017-02-28T00:00:00Z", "P1Y"},
+		{"2016-02-29T00:00:00Z", "2017-02-27T00:00:00Z", "P11M29D"},
+		{"2017-01-31T12:00:00Z", "2017-03-01T06:00:00Z", "P1MT18H"},
+		{"2017-08-16T13:07:00.5Z", "2017-08-17T13:07:00Z", "PT23H59M59.5S"},
+		{"2017-08-16T13:07:00+02:00", "2017-08-16T11:07:00Z", "PT0S"},
+		{"2017-12-31T23:00:00Z", "2018-01-01T01:30:00Z", "PT2H30M"},
+	} {
+		a, err := Parse(v.a)
+		if err != nil {
+			t.Errorf("%s: %s", v.a, err)
+			continue
+		}
+		b, err := Parse(v.b)
+		if err != nil {
+			t.Errorf("%s: %s", v.b, err)
+			continue
+		}
+		if got := Between(a, b).String(); got != v.want {
+			t.Errorf("%s %s: want: %s, got: %s", v.a, v.b, v.want, got)
+		}
+		if got := (CustomTime{Time: a}).AgeAsOf(b).String(); got != v.want {
+			t.Errorf("AgeAsOf %s %s: want: %s, got: %s", v.a, v.b, v.want, got)
+		}
+	}
+}
+
+func TestDuration_ToISO8601(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"P1Y2M3DT4H5M6.5S", "P1Y2M3DT4H5M6.5S"},
+		{"PT0S", "PT0S"},
+		{"-P1D", ""},
+		{"-PT1M30S", ""},
+	} {
+		d, err := ParseDuration(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		got, err := d.ToISO8601()
+		if v.want == "" {
+			if err == nil {
+				t.Errorf("%s: want error, got: %s", v.in, got)
+			}
+			if d.String() != v.in {
+				t.Errorf("%s: want String: %s, got: %s", v.in, v.in, d.String())
+			}
+			continue
+		}
+		if err != nil || got != v.want || got != d.String() {
+			t.Errorf("%s: want: %s, got: %s, %v", v.in, v.want, got, err)
+		}
+	}
+}
+
+var benchDuration = Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 5e8}
+
+func BenchmarkAppendDuration(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = AppendDuration(buf[:0], benchDuration)
+	}
+}
+
+func BenchmarkSprintfDuration(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sprintfDuration(benchDuration)
+	}
+}
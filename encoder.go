@@ -0,0 +1,37 @@
+package xmldatetime
+
+import "time"
+
+// Encoder formats dateTime values with a fixed set of options, reusing a
+// scratch buffer across calls. It is not safe for concurrent use.
+type Encoder struct {
+	c   formatConfig
+	buf []byte
+}
+
+// NewEncoder returns an Encoder configured with opts.
+func NewEncoder(opts ...FormatOption) *Encoder {
+	e := &Encoder{c: formatConfig{precision: 9}}
+	for _, o := range opts {
+		o(&e.c)
+	}
+	return e
+}
+
+// Append appends t in the dateTime lexical representation to dst, as
+// Format does with the Encoder options.
+func (e *Encoder) Append(dst []byte, t time.Time) []byte {
+	if e.c.precision < 9 {
+		t = t.Round(time.Duration(pow10[9-e.c.precision]))
+	}
+	f := fieldsOf(t)
+	dst = appendFraction(appendFields(dst, &f), f.nsec)
+	return appendOffset(dst, f.offset, e.c.zoneMode)
+}
+
+// Encode returns t in the dateTime lexical representation, as Format does
+// with the Encoder options.
+func (e *Encoder) Encode(t time.Time) string {
+	e.buf = e.Append(e.buf[:0], t)
+	return string(e.buf)
+}
@@ -0,0 +1,56 @@
+package xmldatetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncoder(t *testing.T) {
+	for _, v := range []struct {
+		in   time.Time
+		opts []FormatOption
+		want string
+	}{
+		{time.Date(2017, time.August, 16, 13, 7, 0, 92510000, time.FixedZone("", 2*60*60)), nil, "2017-08-16T13:07:00.09251+02:00"},
+		{time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC), nil, "2017-08-16T13:07:00Z"},
+		{time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC), []FormatOption{WithZoneMode(ZoneModeNumeric)}, "2017-08-16T13:07:00+00:00"},
+		{time.Date(2017, time.August, 16, 13, 7, 0, 92510000, time.UTC), []FormatOption{WithOutputPrecision(2)}, "2017-08-16T13:07:00.09Z"},
+		{time.Date(2017, time.August, 16, 23, 59, 59, 999e6, time.UTC), []FormatOption{WithOutputPrecision(0)}, "2017-08-17T00:00:00Z"},
+		{time.Date(-44, time.March, 15, 12, 0, 0, 0, time.FixedZone("", -(5*60+30)*60)), nil, "-0044-03-15T12:00:00-05:30"},
+	} {
+		e := NewEncoder(v.opts...)
+		if got := e.Encode(v.in); got != v.want {
+			t.Errorf("want: %s, got: %s", v.want, got)
+		}
+		if got := Format(v.in, v.opts...); got != v.want {
+			t.Errorf("Format: want: %s, got: %s", v.want, got)
+		}
+		if got := string(e.Append([]byte("at "), v.in)); got != "at "+v.want {
+			t.Errorf("Append: want: at %s, got: %s", v.want, got)
+		}
+	}
+	e := NewEncoder()
+	a := e.Encode(time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC))
+	e.Encode(time.Date(2018, time.August, 16, 13, 7, 0, 0, time.UTC))
+	if a != "2017-08-16T13:07:00Z" {
+		t.Errorf("want earlier result kept, got: %s", a)
+	}
+}
+
+var benchTime = time.Date(2017, time.August, 16, 13, 7, 0, 92510000, time.FixedZone("", 2*60*60))
+
+func BenchmarkEncoderAppend(b *testing.B) {
+	b.ReportAllocs()
+	e := NewEncoder()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = e.Append(buf[:0], benchTime)
+	}
+}
+
+func BenchmarkStringify(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		stringify(benchTime)
+	}
+}
@@ -0,0 +1,144 @@
+package xmldatetime
+
+import (
+	"math"
+	"time"
+)
+
+// FormatOption configures Format.
+type FormatOption func(*formatConfig)
+
+type formatConfig struct {
+	precision int
+	zoneMode  ZoneMode
+}
+
+// ZoneMode selects how a zero offset is written.
+type ZoneMode uint8
+
+const (
+	// ZoneModeCanonical writes a zero offset as 'Z'.
+	ZoneModeCanonical ZoneMode = iota
+	// ZoneModeNumeric writes a zero offset as +00:00.
+	ZoneModeNumeric
+)
+
+// WithZoneMode sets how a zero offset is written, 'Z' by default.
+func WithZoneMode(m ZoneMode) FormatOption {
+	return func(c *formatConfig) {
+		c.zoneMode = m
+	}
+}
+
+// WithOutputPrecision limits the fractional second to at most digits places
+// (0-9) by rounding. Unlike a fixed precision, trailing zeros are still
+// stripped, so fewer digits may be emitted.
+func WithOutputPrecision(digits int) FormatOption {
+	if digits < 0 {
+		digits = 0
+	} else if digits > 9 {
+		digits = 9
+	}
+	return func(c *formatConfig) {
+		c.precision = digits
+	}
+}
+
+// WithFormat returns c formatted with opts by MarshalXML, MarshalXMLAttr
+// and ToCharData. The options are applied once here, so the result is safe
+// to marshal concurrently. Scanning or unmarshaling into c resets them.
+func (c CustomTime) WithFormat(opts ...FormatOption) CustomTime {
+	cfg := newFormatConfig(opts)
+	c.format = &cfg
+	return c
+}
+
+// marshalText returns c in the dateTime lexical representation with the
+// options of WithFormat.
+func (c *CustomTime) marshalText() string {
+	if c.format == nil {
+		return format(c.Time, c.zone, nil)
+	}
+	return c.format.format(c.Time, c.zone)
+}
+
+// Format returns t in the dateTime lexical representation.
+func Format(t time.Time, opts ...FormatOption) string {
+	return format(t, 0, opts)
+}
+
+// format is Format which omits the timezone for ZoneNone.
+func format(t time.Time, zone ZoneForm, opts []FormatOption) string {
+	c := newFormatConfig(opts)
+	return c.format(t, zone)
+}
+
+func newFormatConfig(opts []FormatOption) formatConfig {
+	c := formatConfig{precision: 9}
+	for _, o := range opts {
+		o(&c)
+	}
+	return c
+}
+
+func (c *formatConfig) format(t time.Time, zone ZoneForm) string {
+	if c.precision < 9 {
+		t = t.Round(time.Duration(math.Pow10(9 - c.precision)))
+	}
+	if zone == ZoneNone {
+		return stringifyLocal(t)
+	}
+	_, offset := t.Zone()
+	return string(appendOffset([]byte(stringifyLocal(t)), offset, c.zoneMode))
+}
+
+// Present returns the instant t at the fixed offset of offsetSeconds east
+// of UTC in the dateTime lexical representation, e.g. for systems which
+// require every timestamp in one offset. The offset is always written
+// numerically, +00:00 rather than 'Z'. It panics unless offsetSeconds is a
+// whole number of minutes within ±14:00, the offsets XSD allows.
+func Present(t time.Time, offsetSeconds int) string {
+	mustBeOffset(offsetSeconds)
+	return Format(t.In(fixedZone(offsetSeconds)), WithZoneMode(ZoneModeNumeric))
+}
+
+// HTTPDate returns the instant of c as an HTTP-date, the RFC 1123 form in
+// GMT used by headers such as Last-Modified, e.g.
+// "Wed, 16 Aug 2017 11:07:00 GMT". Fractional seconds are dropped.
+func (c CustomTime) HTTPDate() string {
+	return c.Time.UTC().Format(httpDateLayout)
+}
+
+// httpDateLayout is http.TimeFormat.
+const httpDateLayout = "Mon, 02 Jan 2006 15:04:05 GMT"
+
+// appendZone appends the timezone of t as the zone mode of opts says.
+func appendZone(dst []byte, t time.Time, opts []FormatOption) []byte {
+	var c formatConfig
+	for _, o := range opts {
+		o(&c)
+	}
+	_, offset := t.Zone()
+	return appendOffset(dst, offset, c.zoneMode)
+}
+
+// AppendOffset appends the timezone of offsetSeconds east of UTC to dst,
+// 'Z' for zero and ±hh:mm otherwise. Seconds of the offset are dropped.
+func AppendOffset(dst []byte, offsetSeconds int) []byte {
+	return appendOffset(dst, offsetSeconds, ZoneModeCanonical)
+}
+
+func appendOffset(dst []byte, offset int, mode ZoneMode) []byte {
+	if offset == 0 && mode == ZoneModeCanonical {
+		return append(dst, 'Z')
+	}
+	sign := byte('+')
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	// an offset of 100 hours or more, which only a hand-made location
+	// can have, gets more hour digits rather than garbage
+	dst = appendInt(append(dst, sign), offset/3600, 2)
+	return appendInt(append(dst, ':'), offset/60%60, 2)
+}
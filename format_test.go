@@ -0,0 +1,176 @@
+package xmldatetime
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestFormat_WithOutputPrecision(t *testing.T) {
+	tm := time.Date(2017, time.August, 16, 11, 7, 0, 123456789, time.UTC)
+	for _, v := range []struct {
+		digits int
+		want   string
+	}{
+		{9, "2017-08-16T11:07:00.123456789Z"},
+		{6, "2017-08-16T11:07:00.123457Z"},
+		{3, "2017-08-16T11:07:00.123Z"},
+		{0, "2017-08-16T11:07:00Z"},
+	} {
+		if got := Format(tm, WithOutputPrecision(v.digits)); got != v.want {
+			t.Errorf("digits %d: want: %s, got: %s", v.digits, v.want, got)
+		}
+	}
+
+	// trailing zeros are stripped after clamping
+	tm = time.Date(2017, time.August, 16, 11, 7, 0, 500000400, time.UTC)
+	if got := Format(tm, WithOutputPrecision(6)); got != "2017-08-16T11:07:00.5Z" {
+		t.Errorf("want: 2017-08-16T11:07:00.5Z, got: %s", got)
+	}
+
+	// rounding carries into seconds
+	tm = time.Date(2017, time.August, 16, 11, 7, 59, 999999500, time.UTC)
+	if got := Format(tm, WithOutputPrecision(6)); got != "2017-08-16T11:08:00Z" {
+		t.Errorf("want: 2017-08-16T11:08:00Z, got: %s", got)
+	}
+}
+
+func TestCustomTime_MarshalXMLPrecision(t *testing.T) {
+	c := CustomTime{Time: time.Date(2017, time.August, 16, 11, 7, 0, 123456789, time.UTC)}
+	p := c.WithFormat(WithOutputPrecision(6), WithZoneMode(ZoneModeNumeric))
+	got, err := xml.Marshal(&p)
+	if err != nil {
+		t.Errorf("marshaling: %s", err)
+		t.FailNow()
+	}
+	want := `<CustomTime>2017-08-16T11:07:00.123457+00:00</CustomTime>`
+	if string(got) != want {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+	if got := string(ToCharData(p)); got != "2017-08-16T11:07:00.123457+00:00" {
+		t.Errorf("ToCharData: want: 2017-08-16T11:07:00.123457+00:00, got: %s", got)
+	}
+	if attr, _ := p.MarshalXMLAttr(xml.Name{Local: "at"}); attr.Value != "2017-08-16T11:07:00.123457+00:00" {
+		t.Errorf("MarshalXMLAttr: want: 2017-08-16T11:07:00.123457+00:00, got: %s", attr.Value)
+	}
+	if got, _ := xml.Marshal(&c); string(got) != `<CustomTime>2017-08-16T11:07:00.123456789Z</CustomTime>` {
+		t.Errorf("want c unchanged, got: %s", got)
+	}
+	if !Equal(c, p) {
+		t.Errorf("want the same instant")
+	}
+
+	p = c.WithFormat(WithOutputPrecision(0))
+	if err := xml.Unmarshal([]byte("<t>2017-08-16T13:07:00.123-05:00</t>"), &p); err != nil {
+		t.Errorf("unmarshaling: %s", err)
+	}
+	if got, _ := xml.Marshal(&p); string(got) != `<CustomTime>2017-08-16T13:07:00.123-05:00</CustomTime>` {
+		t.Errorf("want options reset by unmarshaling, got: %s", got)
+	}
+}
+
+func TestAppendOffset(t *testing.T) {
+	for _, v := range []struct {
+		offset int
+		want   string
+	}{
+		{0, "Z"},
+		{5*60*60 + 45*60, "+05:45"},
+		{-(9*60*60 + 30*60), "-09:30"},
+		{14 * 60 * 60, "+14:00"},
+		{-30 * 60, "-00:30"},
+		{100 * 60 * 60, "+100:00"},
+		{-(123*60*60 + 5*60), "-123:05"},
+	} {
+		if got := string(AppendOffset([]byte("x"), v.offset)); got != "x"+v.want {
+			t.Errorf("offset %d: want: x%s, got: %s", v.offset, v.want, got)
+		}
+	}
+	if got := string(appendOffset(nil, 0, ZoneModeNumeric)); got != "+00:00" {
+		t.Errorf("want: +00:00, got: %s", got)
+	}
+}
+
+func TestFormat_Offsets(t *testing.T) {
+	for _, v := range []struct {
+		offset int
+		want   string
+	}{
+		{2 * 60 * 60, "2017-08-16T13:07:00+02:00"},
+		{-(9*60*60 + 30*60), "2017-08-16T13:07:00-09:30"},
+		{-30 * 60, "2017-08-16T13:07:00-00:30"},
+	} {
+		tm := time.Date(2017, time.August, 16, 13, 7, 0, 0, time.FixedZone("", v.offset))
+		if got := Format(tm); got != v.want {
+			t.Errorf("want: %s, got: %s", v.want, got)
+		}
+	}
+	tm := time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC)
+	if got := Format(tm, WithZoneMode(ZoneModeNumeric)); got != "2017-08-16T13:07:00+00:00" {
+		t.Errorf("want: 2017-08-16T13:07:00+00:00, got: %s", got)
+	}
+}
+
+func TestCustomTime_HTTPDate(t *testing.T) {
+	for _, v := range []struct {
+		in, want string
+	}{
+		{"2017-08-16T13:07:00+02:00", "Wed, 16 Aug 2017 11:07:00 GMT"},
+		{"2017-08-16T13:07:00.999Z", "Wed, 16 Aug 2017 13:07:00 GMT"},
+		{"2017-08-16T01:07:00+05:30", "Tue, 15 Aug 2017 19:37:00 GMT"},
+		{"2017-08-16T13:07:00", "Wed, 16 Aug 2017 13:07:00 GMT"},
+	} {
+		var c CustomTime
+		if err := c.Scan(v.in); err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := c.HTTPDate(); got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+	}
+}
+
+func TestPresent(t *testing.T) {
+	in := time.Date(2017, time.August, 16, 11, 7, 0, 5e8, time.UTC)
+	for _, v := range []struct {
+		offset int
+		want   string
+	}{
+		{-4 * 3600, "2017-08-16T07:07:00.5-04:00"},
+		{0, "2017-08-16T11:07:00.5+00:00"},
+		{2 * 3600, "2017-08-16T13:07:00.5+02:00"},
+		{5*3600 + 30*60, "2017-08-16T16:37:00.5+05:30"},
+		{-12 * 3600, "2017-08-15T23:07:00.5-12:00"},
+		{14 * 3600, "2017-08-17T01:07:00.5+14:00"},
+	} {
+		got := Present(in, v.offset)
+		if got != v.want {
+			t.Errorf("%d: want: %s, got: %s", v.offset, v.want, got)
+		}
+		if tm, err := Parse(got); err != nil || !tm.Equal(in) {
+			t.Errorf("%s: want instant %s, got: %s, %v", got, in, tm, err)
+		}
+	}
+}
+
+func TestPresent_OffsetRange(t *testing.T) {
+	in := time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC)
+	c := CustomTime{Time: in, zone: ZoneUTC}
+	for _, offset := range []int{100 * 60 * 60, -15 * 60 * 60, 14*60*60 + 60, 3601} {
+		for name, f := range map[string]func(){
+			"Present":       func() { Present(in, offset) },
+			"InZone":        func() { c.InZone(offset) },
+			"RelabelOffset": func() { c.RelabelOffset(offset) },
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s(%d): want panic", name, offset)
+					}
+				}()
+				f()
+			}()
+		}
+	}
+}
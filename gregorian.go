@@ -0,0 +1,152 @@
+package xmldatetime
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ParseGYear parses the xs:gYear lexical representation '-'? yyyy
+// (zzzzzz)? into midnight of January 1 of the year in its timezone, UTC
+// when there is none. Year 0000 is 1 BCE, as in XSD 1.1.
+func ParseGYear(s string) (time.Time, error) {
+	return defaultDecoder.ParseGYear(s)
+}
+
+// ParseGYear parses an xs:gYear according to the Decoder options; under
+// WithoutYearZero, as in XSD 1.0, year 0000 is rejected.
+func (d *Decoder) ParseGYear(s string) (time.Time, error) {
+	return d.parseGregorian(s, KindGYear)
+}
+
+// ParseGYearMonth parses the xs:gYearMonth lexical representation
+// '-'? yyyy '-' mm (zzzzzz)? into midnight of the first of the month.
+func ParseGYearMonth(s string) (time.Time, error) {
+	return defaultDecoder.parseGregorian(s, KindGYearMonth)
+}
+
+// ParseGMonth parses the xs:gMonth lexical representation '--' mm
+// (zzzzzz)? into midnight of the first of the month in year 0.
+func ParseGMonth(s string) (time.Time, error) {
+	return defaultDecoder.parseGregorian(s, KindGMonth)
+}
+
+// ParseGMonthDay parses the xs:gMonthDay lexical representation
+// '--' mm '-' dd (zzzzzz)? into midnight of the day in year 0, which is a
+// leap year, so --02-29 is valid.
+func ParseGMonthDay(s string) (time.Time, error) {
+	return defaultDecoder.parseGregorian(s, KindGMonthDay)
+}
+
+// ParseGDay parses the xs:gDay lexical representation '---' dd (zzzzzz)?
+// into midnight of the day in January of year 0.
+func ParseGDay(s string) (time.Time, error) {
+	return defaultDecoder.parseGregorian(s, KindGDay)
+}
+
+// parseGregorian parses the Gregorian type k. The fields it lacks are
+// taken from midnight of January 1 of year 0.
+func (d *Decoder) parseGregorian(s string, k Kind) (time.Time, error) {
+	if d.collapse {
+		s = collapse(s)
+	}
+	f := fields{month: 1, day: 1}
+	if len(s) == 0 {
+		return not, errors.New("empty " + k.String())
+	}
+	if len(s) > maxLength {
+		return not, errTooLong
+	}
+	var err error
+	switch k {
+	case KindGYear:
+		s, err = f.lexYear(s)
+	case KindGYearMonth:
+		if s, err = f.lexYear(s); err == nil {
+			if len(s) == 0 || s[0] != '-' {
+				return not, errors.New("expected - in gYearMonth format after 4 digit year")
+			}
+			f.month, s, err = exactInt(s[1:], 2, "two-digit month")
+		}
+	case KindGMonth, KindGMonthDay:
+		if !strings.HasPrefix(s, "--") {
+			return not, errors.New(k.String() + " must start with --")
+		}
+		f.month, s, err = exactInt(s[2:], 2, "two-digit month")
+		if err == nil && k == KindGMonthDay {
+			if len(s) == 0 || s[0] != '-' {
+				return not, errors.New("expected - in gMonthDay format after 2 digit month")
+			}
+			f.day, s, err = exactInt(s[1:], 2, "two-digit day")
+		}
+	case KindGDay:
+		if !strings.HasPrefix(s, "---") {
+			return not, errors.New("gDay must start with ---")
+		}
+		f.day, s, err = exactInt(s[3:], 2, "two-digit day")
+	}
+	if err != nil {
+		return not, err
+	}
+	if f.offset, f.zone, err = d.parseOffset(s); err != nil {
+		return not, err
+	}
+	if err := f.checkDate(); err != nil {
+		return not, err
+	}
+	if d.requireTimezone {
+		if err := f.checkTimezone(); err != nil {
+			return not, err
+		}
+	}
+	if d.noYearZero && f.year == 0 && (k == KindGYear || k == KindGYearMonth) {
+		return not, errors.New("year 0000 is not allowed")
+	}
+	return f.time(), nil
+}
+
+// FormatGYear returns the year of t in the xs:gYear lexical
+// representation, e.g. 2017Z, with its timezone written per opts. Years
+// before 1 BCE get a leading '-' and all are padded to four digits, e.g.
+// -0044; year 0 is written as 0000.
+func FormatGYear(t time.Time, opts ...FormatOption) string {
+	return string(appendZone(appendYear(make([]byte, 0, 16), t.Year()), t, opts))
+}
+
+// FormatGYearMonth returns t in the xs:gYearMonth lexical representation,
+// e.g. 2017-08Z.
+func FormatGYearMonth(t time.Time, opts ...FormatOption) string {
+	b := append(appendYear(make([]byte, 0, 16), t.Year()), '-')
+	b = appendInt(b, int(t.Month()), 2)
+	return string(appendZone(b, t, opts))
+}
+
+// FormatGMonth returns t in the xs:gMonth lexical representation, e.g.
+// --08Z.
+func FormatGMonth(t time.Time, opts ...FormatOption) string {
+	b := appendInt(append(make([]byte, 0, 16), "--"...), int(t.Month()), 2)
+	return string(appendZone(b, t, opts))
+}
+
+// FormatGMonthDay returns t in the xs:gMonthDay lexical representation,
+// e.g. --08-16Z.
+func FormatGMonthDay(t time.Time, opts ...FormatOption) string {
+	b := appendInt(append(make([]byte, 0, 16), "--"...), int(t.Month()), 2)
+	b = appendInt(append(b, '-'), t.Day(), 2)
+	return string(appendZone(b, t, opts))
+}
+
+// FormatGDay returns t in the xs:gDay lexical representation, e.g. ---16Z.
+func FormatGDay(t time.Time, opts ...FormatOption) string {
+	b := appendInt(append(make([]byte, 0, 16), "---"...), t.Day(), 2)
+	return string(appendZone(b, t, opts))
+}
+
+// appendYear appends '-'? yyyy.
+func appendYear(b []byte, year int) []byte {
+	if year < 0 {
+		b = append(b, '-')
+		year = -year
+	}
+	return appendInt(b, year, 4)
+}
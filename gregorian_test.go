@@ -0,0 +1,79 @@
+package xmldatetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatGregorian(t *testing.T) {
+	utc := time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC)
+	zoned := time.Date(-44, time.March, 5, 13, 7, 0, 0, time.FixedZone("", -(5*60+30)*60))
+	for _, v := range []struct {
+		name   string
+		format func(time.Time, ...FormatOption) string
+		utc    string
+		zoned  string
+	}{
+		{"gYear", FormatGYear, "2017", "-0044-05:30"},
+		{"gYearMonth", FormatGYearMonth, "2017-08", "-0044-03-05:30"},
+		{"gMonth", FormatGMonth, "--08", "--03-05:30"},
+		{"gMonthDay", FormatGMonthDay, "--08-16", "--03-05-05:30"},
+		{"gDay", FormatGDay, "---16", "---05-05:30"},
+		{"date", FormatDate, "2017-08-16", "-0044-03-05-05:30"},
+		{"time", FormatTime, "13:07:00", "13:07:00-05:30"},
+	} {
+		if got := v.format(utc); got != v.utc+"Z" {
+			t.Errorf("%s: want: %sZ, got: %s", v.name, v.utc, got)
+		}
+		if got := v.format(utc, WithZoneMode(ZoneModeCanonical)); got != v.utc+"Z" {
+			t.Errorf("%s canonical: want: %sZ, got: %s", v.name, v.utc, got)
+		}
+		if got := v.format(utc, WithZoneMode(ZoneModeNumeric)); got != v.utc+"+00:00" {
+			t.Errorf("%s numeric: want: %s+00:00, got: %s", v.name, v.utc, got)
+		}
+		if got := v.format(zoned, WithZoneMode(ZoneModeNumeric)); got != v.zoned {
+			t.Errorf("%s: want: %s, got: %s", v.name, v.zoned, got)
+		}
+	}
+}
+
+func TestParseFormatGYear(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		year int
+		want string
+	}{
+		{"2017", 2017, "2017Z"},
+		{"2017+02:00", 2017, "2017+02:00"},
+		{"-0044", -44, "-0044Z"},
+		{"-0001Z", -1, "-0001Z"},
+		{"0000", 0, "0000Z"},
+		{"0001-05:00", 1, "0001-05:00"},
+	} {
+		tm, err := ParseGYear(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if tm.Year() != v.year || tm.Month() != time.January || tm.Day() != 1 {
+			t.Errorf("%s: want January 1 of %d, got: %s", v.in, v.year, tm)
+		}
+		if got := FormatGYear(tm); got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+	}
+	for _, v := range []string{"", "17", "+2017", "2017-08", "2017Z ", "-44"} {
+		if _, err := ParseGYear(v); err == nil {
+			t.Errorf("want error, got nil: %q", v)
+		}
+	}
+	if _, err := NewStrictXSD10().ParseGYear("0000"); err == nil {
+		t.Errorf("XSD 1.0: want year 0000 rejected, got nil")
+	}
+	if _, err := NewStrictXSD10().ParseGYear("-0001"); err != nil {
+		t.Errorf("XSD 1.0: %s", err)
+	}
+	if _, err := NewLenient().ParseGYear(" 2017+02 "); err != nil {
+		t.Errorf("lenient: %s", err)
+	}
+}
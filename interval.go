@@ -0,0 +1,59 @@
+package xmldatetime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Interval is the half-open time range [Start, End): it contains Start but
+// not End.
+type Interval struct {
+	Start, End time.Time
+}
+
+// ParseInterval parses an ISO 8601 time interval of two dateTime values
+// joined by '/', e.g. 2017-08-16T13:07:00Z/2017-08-16T14:07:00Z. End must
+// be after Start.
+func ParseInterval(s string) (Interval, error) {
+	from, to, ok := strings.Cut(s, "/")
+	if !ok {
+		return Interval{}, errors.New("interval requires start/end")
+	}
+	return ParseWindow(from, to)
+}
+
+// ParseWindow parses the bounds of an interval given separately, e.g. by
+// the from and to attributes of an element, collapsing surrounding
+// whitespace. to must be after from.
+func ParseWindow(from, to string) (Interval, error) {
+	start, err := Parse(collapse(from))
+	if err != nil {
+		return Interval{}, fmt.Errorf("interval start: %w", err)
+	}
+	end, err := Parse(collapse(to))
+	if err != nil {
+		return Interval{}, fmt.Errorf("interval end: %w", err)
+	}
+	if !end.After(start) {
+		return Interval{}, errors.New("interval end must be after its start")
+	}
+	return Interval{Start: start, End: end}, nil
+}
+
+// Contains reports whether t is within i, which includes Start and
+// excludes End.
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.Start) && t.Before(i.End)
+}
+
+// Duration returns the length of i.
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// String returns i as start/end.
+func (i Interval) String() string {
+	return Format(i.Start) + "/" + Format(i.End)
+}
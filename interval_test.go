@@ -0,0 +1,66 @@
+package xmldatetime
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	i, err := ParseInterval("2017-08-16T13:07:00+02:00/2017-08-16T12:07:00Z")
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if d := i.Duration(); d != time.Hour {
+		t.Errorf("want 1h, got: %s", d)
+	}
+	if got := i.String(); got != "2017-08-16T13:07:00+02:00/2017-08-16T12:07:00Z" {
+		t.Errorf("want: 2017-08-16T13:07:00+02:00/2017-08-16T12:07:00Z, got: %s", got)
+	}
+	for _, v := range []string{
+		"2017-08-16T13:07:00Z",
+		"2017-08-16T13:07:00Z/",
+		"2017-08-16T13:07:00Z/2017-08-16T13:07:00Z",
+		"2017-08-16T14:07:00Z/2017-08-16T13:07:00Z",
+	} {
+		if _, err := ParseInterval(v); err == nil {
+			t.Errorf("want error, got nil: %s", v)
+		}
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	var w struct {
+		From string `xml:"from,attr"`
+		To   string `xml:"to,attr"`
+	}
+	if err := xml.Unmarshal([]byte(`<window from=" 2017-08-16T13:00:00Z" to="2017-08-16T14:00:00Z "/>`), &w); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	i, err := ParseWindow(w.From, w.To)
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	for _, v := range []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2017, time.August, 16, 12, 59, 59, 0, time.UTC), false},
+		{time.Date(2017, time.August, 16, 13, 0, 0, 0, time.UTC), true},
+		{time.Date(2017, time.August, 16, 13, 59, 59, 999999999, time.UTC), true},
+		{time.Date(2017, time.August, 16, 14, 0, 0, 0, time.UTC), false},
+	} {
+		if got := i.Contains(v.at); got != v.want {
+			t.Errorf("%s: want: %v, got: %v", v.at, v.want, got)
+		}
+	}
+	if _, err := ParseWindow("2017-08-16T14:00:00Z", "2017-08-16T13:00:00Z"); err == nil {
+		t.Errorf("inverted: want error, got nil")
+	}
+	if _, err := ParseWindow("2017-08-16T13:00:00Z", "2017-08-16"); err == nil {
+		t.Errorf("invalid end: want error, got nil")
+	}
+}
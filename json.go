@@ -0,0 +1,32 @@
+package xmldatetime
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrNull is returned by ParseJSON for the JSON null literal.
+var ErrNull = errors.New("null value")
+
+// ParseJSON parses a dateTime held as a JSON string. For null it returns
+// the zero time and ErrNull; any other JSON value is an error.
+func ParseJSON(raw json.RawMessage) (time.Time, error) {
+	raw = bytes.TrimSpace(raw)
+	if string(raw) == "null" {
+		return not, ErrNull
+	}
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return not, errors.New("dateTime in JSON must be a string")
+	}
+	if bytes.IndexByte(raw, '\\') < 0 {
+		return ParseBytes(raw[1 : len(raw)-1])
+	}
+	// only escaped strings need the full JSON decoding
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return not, err
+	}
+	return Parse(s)
+}
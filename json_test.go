@@ -0,0 +1,35 @@
+package xmldatetime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseJSON(t *testing.T) {
+	ex := time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC)
+	for _, v := range []string{
+		`"2017-08-16T13:07:00+02:00"`,
+		` "2017-08-16T11:07:00Z"` + "\n",
+		`"2017-08-16T13:07:00\u002b02:00"`,
+	} {
+		tm, err := ParseJSON(json.RawMessage(v))
+		if err != nil {
+			t.Errorf("%s: %s", v, err)
+			continue
+		}
+		if !tm.Equal(ex) {
+			t.Errorf("%s: want: %s, got: %s", v, ex, tm)
+		}
+	}
+
+	tm, err := ParseJSON(json.RawMessage("null"))
+	if err != ErrNull || !tm.IsZero() {
+		t.Errorf("null: want zero time and ErrNull, got: %s, %v", tm, err)
+	}
+	for _, v := range []string{`1502881620`, `{"t":"2017-08-16T11:07:00Z"}`, `"2017-08-16"`, `"`, ``} {
+		if _, err := ParseJSON(json.RawMessage(v)); err == nil || err == ErrNull {
+			t.Errorf("%s: want error, got: %v", v, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package xmldatetime
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Kind is an XSD date/time datatype.
+type Kind uint8
+
+const (
+	KindUnknown Kind = iota
+	// KindDateTime is xs:dateTime.
+	KindDateTime
+	// KindDateTimeStamp is xs:dateTimeStamp, a dateTime with timezone.
+	KindDateTimeStamp
+	// KindDate is xs:date.
+	KindDate
+	// KindTime is xs:time.
+	KindTime
+	// KindGYear is xs:gYear, e.g. 2017.
+	KindGYear
+	// KindGYearMonth is xs:gYearMonth, e.g. 2017-08.
+	KindGYearMonth
+	// KindGMonth is xs:gMonth, e.g. --08.
+	KindGMonth
+	// KindGMonthDay is xs:gMonthDay, e.g. --08-16.
+	KindGMonthDay
+	// KindGDay is xs:gDay, e.g. ---16.
+	KindGDay
+)
+
+var kindNames = [...]string{
+	KindUnknown:       "unknown",
+	KindDateTime:      "dateTime",
+	KindDateTimeStamp: "dateTimeStamp",
+	KindDate:          "date",
+	KindTime:          "time",
+	KindGYear:         "gYear",
+	KindGYearMonth:    "gYearMonth",
+	KindGMonth:        "gMonth",
+	KindGMonthDay:     "gMonthDay",
+	KindGDay:          "gDay",
+}
+
+// String returns the XSD name of the datatype.
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return kindNames[KindUnknown]
+}
+
+// Detect returns the datatype of the valid date/time value s, KindUnknown
+// when s is not valid for any. A dateTime with timezone is reported as
+// KindDateTimeStamp.
+func Detect(s string) Kind {
+	_, k, err := ParseAny(s)
+	if err != nil {
+		return KindUnknown
+	}
+	return k
+}
+
+// ParseAny parses s as whichever XSD date/time datatype its shape matches
+// and returns the value along with the datatype, as the parser of that
+// type would. The timezone is set aside first, so that e.g. --05-05:00 is
+// a gMonth with an offset while --05-05 is a gMonthDay, and -0005 is a
+// gYear.
+func ParseAny(s string) (time.Time, Kind, error) {
+	body := strings.TrimSuffix(s, "Z")
+	if n := len(body); n == len(s) && n >= 6 && body[n-3] == ':' && (body[n-6] == '+' || body[n-6] == '-') {
+		body = body[:n-6]
+	}
+	switch {
+	case strings.IndexByte(body, 'T') >= 0:
+		f, err := parse(s)
+		if err != nil {
+			return not, KindUnknown, err
+		}
+		if f.zone == ZoneNone {
+			return f.time(), KindDateTime, nil
+		}
+		return f.time(), KindDateTimeStamp, nil
+	case strings.HasPrefix(body, "---"):
+		return parseKind(s, KindGDay)
+	case strings.HasPrefix(body, "--") && len(body) == 4:
+		return parseKind(s, KindGMonth)
+	case strings.HasPrefix(body, "--"):
+		return parseKind(s, KindGMonthDay)
+	case len(body) > 2 && body[2] == ':':
+		t, err := ParseTime(s)
+		return t, kindOf(KindTime, err), err
+	}
+	switch len(strings.TrimPrefix(body, "-")) {
+	case 4:
+		return parseKind(s, KindGYear)
+	case 7:
+		return parseKind(s, KindGYearMonth)
+	case 10:
+		t, err := ParseDate(s)
+		return t, kindOf(KindDate, err), err
+	}
+	return not, KindUnknown, errors.New("unknown date/time datatype")
+}
+
+// PossibleKinds returns every datatype s is valid for, in Kind order, by
+// trying the parser of each one. Unlike Detect it does not guess from the
+// shape, so a dateTime with timezone is both KindDateTime and
+// KindDateTimeStamp. It returns nil when s is valid for none.
+func PossibleKinds(s string) []Kind {
+	var kinds []Kind
+	if f, err := parse(s); err == nil {
+		kinds = append(kinds, KindDateTime)
+		if f.zone != ZoneNone {
+			kinds = append(kinds, KindDateTimeStamp)
+		}
+	}
+	if _, err := parseDate(s); err == nil {
+		kinds = append(kinds, KindDate)
+	}
+	if _, err := parseTime(s); err == nil {
+		kinds = append(kinds, KindTime)
+	}
+	for k := KindGYear; k <= KindGDay; k++ {
+		if _, err := defaultDecoder.parseGregorian(s, k); err == nil {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+func parseKind(s string, k Kind) (time.Time, Kind, error) {
+	t, err := defaultDecoder.parseGregorian(s, k)
+	return t, kindOf(k, err), err
+}
+
+func kindOf(k Kind, err error) Kind {
+	if err != nil {
+		return KindUnknown
+	}
+	return k
+}
@@ -0,0 +1,114 @@
+package xmldatetime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseAny(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		kind Kind
+		want time.Time
+	}{
+		{"2017-08-16T13:07:00", KindDateTime, time.Date(2017, time.August, 16, 13, 7, 0, 0, time.UTC)},
+		{"2017-08-16T13:07:00+02:00", KindDateTimeStamp, time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC)},
+		{"2017-08-16", KindDate, time.Date(2017, time.August, 16, 0, 0, 0, 0, time.UTC)},
+		{"2017-08-16-05:00", KindDate, time.Date(2017, time.August, 16, 5, 0, 0, 0, time.UTC)},
+		{"13:07:00.5Z", KindTime, time.Date(0, time.January, 1, 13, 7, 0, 5e8, time.UTC)},
+		{"2017", KindGYear, time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"-0005", KindGYear, time.Date(-5, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"-0005-05:00", KindGYear, time.Date(-5, time.January, 1, 5, 0, 0, 0, time.UTC)},
+		{"2017-08", KindGYearMonth, time.Date(2017, time.August, 1, 0, 0, 0, 0, time.UTC)},
+		{"2017-08-05:00", KindGYearMonth, time.Date(2017, time.August, 1, 5, 0, 0, 0, time.UTC)},
+		{"--05", KindGMonth, time.Date(0, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{"--05-05:00", KindGMonth, time.Date(0, time.May, 1, 5, 0, 0, 0, time.UTC)},
+		{"--05Z", KindGMonth, time.Date(0, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{"--05-15", KindGMonthDay, time.Date(0, time.May, 15, 0, 0, 0, 0, time.UTC)},
+		{"--02-29+02:00", KindGMonthDay, time.Date(0, time.February, 28, 22, 0, 0, 0, time.UTC)},
+		{"---15", KindGDay, time.Date(0, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{"---31Z", KindGDay, time.Date(0, time.January, 31, 0, 0, 0, 0, time.UTC)},
+	} {
+		tm, k, err := ParseAny(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if k != v.kind {
+			t.Errorf("%s: want kind: %s, got: %s", v.in, v.kind, k)
+		}
+		if !tm.Equal(v.want) {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, tm)
+		}
+		if got := Detect(v.in); got != v.kind {
+			t.Errorf("Detect(%s): want: %s, got: %s", v.in, v.kind, got)
+		}
+	}
+	for _, v := range []string{"", "Z", "--13", "--04-31", "---32", "----15", "-005", "2017-8", "13:07", "--5", "2017-08-16T25:00:00", "P1D"} {
+		if _, k, err := ParseAny(v); err == nil || k != KindUnknown {
+			t.Errorf("%q: want error, got: %s, %v", v, k, err)
+		}
+		if k := Detect(v); k != KindUnknown {
+			t.Errorf("Detect(%q): want unknown, got: %s", v, k)
+		}
+	}
+}
+
+func TestPossibleKinds(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want []Kind
+	}{
+		{"2017", []Kind{KindGYear}},
+		{"--05", []Kind{KindGMonth}},
+		{"--05-05:00", []Kind{KindGMonth}},
+		{"2017-08-16", []Kind{KindDate}},
+		{"2017-08-16T13:07:00", []Kind{KindDateTime}},
+		{"2017-08-16T13:07:00Z", []Kind{KindDateTime, KindDateTimeStamp}},
+		{"13:07:00", []Kind{KindTime}},
+		{"P1D", nil},
+	} {
+		got := PossibleKinds(v.in)
+		if !reflect.DeepEqual(got, v.want) {
+			t.Errorf("%s: want: %v, got: %v", v.in, v.want, got)
+		}
+	}
+}
+
+func TestParseGregorian(t *testing.T) {
+	for _, v := range []struct {
+		in     string
+		parse  func(string) (time.Time, error)
+		format func(time.Time, ...FormatOption) string
+		want   string
+	}{
+		{"2017-08+02:00", ParseGYearMonth, FormatGYearMonth, "2017-08+02:00"},
+		{"--08", ParseGMonth, FormatGMonth, "--08Z"},
+		{"--08-16-05:30", ParseGMonthDay, FormatGMonthDay, "--08-16-05:30"},
+		{"---16", ParseGDay, FormatGDay, "---16Z"},
+	} {
+		tm, err := v.parse(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if got := v.format(tm); got != v.want {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, got)
+		}
+	}
+	for _, v := range []struct {
+		in    string
+		parse func(string) (time.Time, error)
+	}{
+		{"2017", ParseGYearMonth},
+		{"--08-16", ParseGMonth},
+		{"--08", ParseGMonthDay},
+		{"--16", ParseGDay},
+		{"08", ParseGMonth},
+	} {
+		if _, err := v.parse(v.in); err == nil {
+			t.Errorf("%s: want error, got nil", v.in)
+		}
+	}
+}
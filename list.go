@@ -0,0 +1,125 @@
+package xmldatetime
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ListError reports an invalid item of an xs:list value.
+type ListError struct {
+	Index int
+	Err   error
+}
+
+func (e *ListError) Error() string {
+	return fmt.Sprintf("list item %d: %s", e.Index, e.Err)
+}
+
+func (e *ListError) Unwrap() error {
+	return e.Err
+}
+
+// ParseList parses an xs:list of dateTime values separated by XML
+// whitespace. An invalid item is reported as a *ListError.
+func ParseList(s string) ([]time.Time, error) {
+	return defaultDecoder.ParseList(s)
+}
+
+// ParseList parses a list of dateTime values according to the Decoder
+// options, separated by XML whitespace or by the WithListSeparator rune.
+// An invalid item is reported as a *ListError.
+func (d *Decoder) ParseList(s string) ([]time.Time, error) {
+	var items []string
+	if d.listSep != 0 {
+		items = strings.Split(s, string(d.listSep))
+		for i := range items {
+			items[i] = collapse(items[i])
+		}
+		if len(items) == 1 && items[0] == "" {
+			items = nil
+		}
+	} else {
+		items = splitList(s)
+	}
+	res := make([]time.Time, 0, len(items))
+	for i, v := range items {
+		t, err := d.Parse(v)
+		if err != nil {
+			return nil, &ListError{Index: i, Err: err}
+		}
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+// ParseListReader parses an xs:list of dateTime values separated by XML
+// whitespace from r and calls fn with each one, without reading the whole
+// list into memory. An invalid item is reported as a *ListError, an error
+// of fn is returned as is and stops the parsing.
+func ParseListReader(r io.Reader, fn func(time.Time) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Split(scanListItems)
+	for i := 0; sc.Scan(); i++ {
+		t, err := Parse(sc.Text())
+		if err != nil {
+			return &ListError{Index: i, Err: err}
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// scanListItems is a bufio.SplitFunc returning the items of an xs:list. An
+// item cut by the end of data is only returned once the rest is read.
+func scanListItems(data []byte, atEOF bool) (int, []byte, error) {
+	start := 0
+	for start < len(data) && isSpace(rune(data[start])) {
+		start++
+	}
+	for i := start; i < len(data); i++ {
+		if isSpace(rune(data[i])) {
+			return i + 1, data[start:i], nil
+		}
+	}
+	if atEOF && start < len(data) {
+		return len(data), data[start:], nil
+	}
+	return start, nil, nil
+}
+
+// ParseDurationList parses an xs:list of duration values separated by XML
+// whitespace. An invalid item is reported as a *ListError.
+func ParseDurationList(s string) ([]Duration, error) {
+	items := splitList(s)
+	res := make([]Duration, 0, len(items))
+	for i, v := range items {
+		d, err := ParseDuration(v)
+		if err != nil {
+			return nil, &ListError{Index: i, Err: err}
+		}
+		res = append(res, d)
+	}
+	return res, nil
+}
+
+// FormatDurationList returns ds as an xs:list, separated by single spaces.
+func FormatDurationList(ds []Duration) string {
+	items := make([]string, len(ds))
+	for i, d := range ds {
+		items[i] = d.String()
+	}
+	return strings.Join(items, " ")
+}
+
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, isSpace)
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
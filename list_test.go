@@ -0,0 +1,169 @@
+package xmldatetime
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestParseList(t *testing.T) {
+	got, err := ParseList(" 2017-08-16T13:07:00+02:00\n\t2017-08-16T11:08:00Z ")
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	want := []time.Time{
+		time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC),
+		time.Date(2017, time.August, 16, 11, 8, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Errorf("want %d values, got: %d", len(want), len(got))
+		t.FailNow()
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("%d: want: %s, got: %s", i, want[i], got[i])
+		}
+	}
+
+	_, err = ParseList("2017-08-16T13:07:00Z 2017-08-16")
+	var le *ListError
+	if !errors.As(err, &le) || le.Index != 1 {
+		t.Errorf("want list error at 1, got: %v", err)
+	}
+}
+
+func TestParseListReader(t *testing.T) {
+	// the first value straddles the 4096 byte buffer of bufio.Scanner
+	in := strings.Repeat(" ", 4090) + "2017-08-16T13:07:00+02:00\n\t2017-08-16T11:08:00Z "
+	want := []time.Time{
+		time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC),
+		time.Date(2017, time.August, 16, 11, 8, 0, 0, time.UTC),
+	}
+	for _, r := range []io.Reader{strings.NewReader(in), iotest.OneByteReader(strings.NewReader(in))} {
+		var got []time.Time
+		err := ParseListReader(r, func(t time.Time) error {
+			got = append(got, t)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("error: %s", err)
+			continue
+		}
+		if len(got) != len(want) {
+			t.Errorf("want %d values, got: %d", len(want), len(got))
+			continue
+		}
+		for i := range want {
+			if !got[i].Equal(want[i]) {
+				t.Errorf("%d: want: %s, got: %s", i, want[i], got[i])
+			}
+		}
+	}
+
+	err := ParseListReader(strings.NewReader("2017-08-16T13:07:00Z 2017-08-16"), func(time.Time) error { return nil })
+	var le *ListError
+	if !errors.As(err, &le) || le.Index != 1 {
+		t.Errorf("want list error at 1, got: %v", err)
+	}
+	stop := errors.New("stop")
+	n := 0
+	err = ParseListReader(strings.NewReader(in), func(time.Time) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 {
+		t.Errorf("want stop after 1 value, got: %v after %d", err, n)
+	}
+}
+
+func TestWithListSeparator(t *testing.T) {
+	want := []time.Time{
+		time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC),
+		time.Date(2017, time.August, 16, 11, 8, 0, 0, time.UTC),
+	}
+	for _, v := range []struct {
+		d  *Decoder
+		in string
+	}{
+		{NewDecoder(), "2017-08-16T13:07:00+02:00 \n2017-08-16T11:08:00Z"},
+		{NewDecoder(WithListSeparator(',')), "2017-08-16T13:07:00+02:00,2017-08-16T11:08:00Z"},
+		{NewDecoder(WithListSeparator(',')), " 2017-08-16T13:07:00+02:00 ,\n2017-08-16T11:08:00Z "},
+		{NewDecoder(WithListSeparator(';')), "2017-08-16T13:07:00+02:00;2017-08-16T11:08:00Z"},
+	} {
+		got, err := v.d.ParseList(v.in)
+		if err != nil {
+			t.Errorf("%q: %s", v.in, err)
+			continue
+		}
+		if len(got) != len(want) {
+			t.Errorf("%q: want %d values, got: %d", v.in, len(want), len(got))
+			continue
+		}
+		for i := range want {
+			if !got[i].Equal(want[i]) {
+				t.Errorf("%q %d: want: %s, got: %s", v.in, i, want[i], got[i])
+			}
+		}
+	}
+
+	d := NewDecoder(WithListSeparator(','))
+	if got, err := d.ParseList(" "); err != nil || len(got) != 0 {
+		t.Errorf("want empty list, got: %v, %v", got, err)
+	}
+	for _, v := range []struct {
+		in    string
+		index int
+	}{
+		{"2017-08-16T13:07:00Z 2017-08-16T11:08:00Z", 0},
+		{"2017-08-16T13:07:00Z,,2017-08-16T11:08:00Z", 1},
+		{"2017-08-16T13:07:00Z,", 1},
+	} {
+		_, err := d.ParseList(v.in)
+		var le *ListError
+		if !errors.As(err, &le) || le.Index != v.index {
+			t.Errorf("%q: want list error at %d, got: %v", v.in, v.index, err)
+		}
+	}
+	if _, err := ParseList("2017-08-16T13:07:00Z,2017-08-16T11:08:00Z"); err == nil {
+		t.Errorf("ParseList: want error for comma, got nil")
+	}
+}
+
+func TestParseDurationList(t *testing.T) {
+	got, err := ParseDurationList("P1Y -PT5M\n PT0.5S -P2D")
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	want := []Duration{{Years: 1}, {Negative: true, Minutes: 5}, {Nanoseconds: 5e8}, {Negative: true, Days: 2}}
+	if len(got) != len(want) {
+		t.Errorf("want %d values, got: %d", len(want), len(got))
+		t.FailNow()
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%d: want: %+v, got: %+v", i, want[i], got[i])
+		}
+	}
+	if s := FormatDurationList(got); s != "P1Y -PT5M PT0.5S -P2D" {
+		t.Errorf("want: P1Y -PT5M PT0.5S -P2D, got: %s", s)
+	}
+
+	got, err = ParseDurationList(" \n ")
+	if err != nil || len(got) != 0 {
+		t.Errorf("empty list: want no values, got: %v, %v", got, err)
+	}
+	if s := FormatDurationList(nil); s != "" {
+		t.Errorf("want empty string, got: %s", s)
+	}
+
+	_, err = ParseDurationList("P1Y P1X PT1S")
+	var le *ListError
+	if !errors.As(err, &le) || le.Index != 1 {
+		t.Errorf("want list error at 1, got: %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package xmldatetime
+
+import "encoding/xml"
+
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// NullCustomTime is a CustomTime which may be null, written in XML as an
+// element with xsi:nil="true".
+type NullCustomTime struct {
+	CustomTime
+	Valid bool
+}
+
+func (n *NullCustomTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if isNil(start) {
+		*n = NullCustomTime{}
+		return d.Skip()
+	}
+	if err := n.CustomTime.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+func (n *NullCustomTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if n.Valid {
+		return n.CustomTime.MarshalXML(e, start)
+	}
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+		xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"})
+	return e.EncodeElement("", start)
+}
+
+// isNil reports whether the element has xsi:nil set to true. An undeclared
+// xsi prefix is accepted too.
+func isNil(start xml.StartElement) bool {
+	for _, a := range start.Attr {
+		if a.Name.Local == "nil" && (a.Name.Space == xsiNamespace || a.Name.Space == "xsi") {
+			v := collapse(a.Value)
+			return v == "true" || v == "1"
+		}
+	}
+	return false
+}
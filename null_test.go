@@ -0,0 +1,53 @@
+package xmldatetime
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestNullCustomTime_XML(t *testing.T) {
+	type doc struct {
+		T NullCustomTime `xml:"t"`
+	}
+	for _, in := range []string{
+		`<r xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><t xsi:nil="true"/></r>`,
+		`<r><t xsi:nil="true"></t></r>`,
+		`<r><t xsi:nil="1">ignored</t></r>`,
+	} {
+		v := doc{T: NullCustomTime{Valid: true}}
+		if err := xml.Unmarshal([]byte(in), &v); err != nil {
+			t.Errorf("%s: %s", in, err)
+			continue
+		}
+		if v.T.Valid || !v.T.IsZero() {
+			t.Errorf("%s: want null, got: %+v", in, v.T)
+		}
+	}
+
+	var v doc
+	if err := xml.Unmarshal([]byte(`<r><t xsi:nil="false">2017-08-16T13:07:00+02:00</t></r>`), &v); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	ex := time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC)
+	if !v.T.Valid || !v.T.Time.Equal(ex) {
+		t.Errorf("want: %s, got: %+v", ex, v.T)
+	}
+
+	var c CustomTime
+	if err := xml.Unmarshal([]byte(`<t xsi:nil="true">x</t>`), &c); err != nil || !c.IsZero() {
+		t.Errorf("CustomTime: want zero value, got: %s, %v", c.Time, err)
+	}
+
+	got, err := xml.Marshal(&doc{})
+	want := `<doc><t xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></t></doc>`
+	if err != nil || string(got) != want {
+		t.Errorf("want: %s, got: %s, %v", want, got, err)
+	}
+	got, err = xml.Marshal(&v)
+	want = `<doc><t>2017-08-16T13:07:00+02:00</t></doc>`
+	if err != nil || string(got) != want {
+		t.Errorf("want: %s, got: %s, %v", want, got, err)
+	}
+}
@@ -0,0 +1,30 @@
+package xmldatetime
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Random returns a valid dateTime value drawn from r, for property tests.
+// The year is -9999 to 9999, the range of the lexer including year 0000,
+// the offset a quarter hour within ±14:00 and the fraction has 0-9 digits.
+func Random(r *rand.Rand) time.Time {
+	year := r.Intn(2*9999+1) - 9999
+	month := time.Month(1 + r.Intn(12))
+	day := 1 + r.Intn(daysIn(month, year))
+	nsec := r.Intn(1e9)
+	nsec -= nsec % pow10[r.Intn(10)]
+	offset := (r.Intn(2*14*4+1) - 14*4) * 15 * 60
+	return time.Date(year, month, day, r.Intn(24), r.Intn(60), r.Intn(60), nsec, fixedZone(offset))
+}
+
+// RandomString returns a value of Random in the dateTime lexical
+// representation. A third of the values are zoneless, the others have
+// 'Z' or an offset.
+func RandomString(r *rand.Rand) string {
+	t := Random(r)
+	if r.Intn(3) == 0 {
+		return format(t, ZoneNone, nil)
+	}
+	return Format(t)
+}
@@ -0,0 +1,37 @@
+package xmldatetime
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestRandomString(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var zoneless, utc, offset, negative int
+	for i := 0; i < 10000; i++ {
+		s := RandomString(r)
+		var c CustomTime
+		if err := c.Scan(s); err != nil {
+			t.Errorf("%s: %s", s, err)
+			continue
+		}
+		if got, _ := c.Value(); got != s {
+			t.Errorf("%s: round trip gave %v", s, got)
+		}
+		switch {
+		case c.IsZoneless():
+			zoneless++
+		case strings.HasSuffix(s, "Z"):
+			utc++
+		default:
+			offset++
+		}
+		if c.Year() <= 0 {
+			negative++
+		}
+	}
+	if zoneless == 0 || utc == 0 || offset == 0 || negative == 0 {
+		t.Errorf("want every zone form and years <= 0, got zoneless: %d, Z: %d, offset: %d, years <= 0: %d", zoneless, utc, offset, negative)
+	}
+}
@@ -0,0 +1,44 @@
+package xmldatetime
+
+import "time"
+
+// Result is a parsed dateTime along with details of its lexical form.
+type Result struct {
+	t      time.Time
+	zone   ZoneForm
+	digits int
+}
+
+// ParseResult parses s like Parse, keeping the details of its lexical form.
+func ParseResult(s string) (Result, error) {
+	f, err := parse(s)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{t: f.time(), zone: f.zone, digits: f.digits}, nil
+}
+
+// Time returns the parsed time, as Parse does.
+func (r Result) Time() time.Time {
+	return r.t
+}
+
+// ZoneForm returns how the timezone was written.
+func (r Result) ZoneForm() ZoneForm {
+	return r.zone
+}
+
+// FractionDigits returns the number of digits of the fractional second as
+// written, including trailing zeros.
+func (r Result) FractionDigits() int {
+	return r.digits
+}
+
+// Kind returns the most specific datatype of the value: KindDateTimeStamp
+// when a timezone is present, KindDateTime otherwise.
+func (r Result) Kind() Kind {
+	if r.zone == ZoneNone {
+		return KindDateTime
+	}
+	return KindDateTimeStamp
+}
@@ -0,0 +1,41 @@
+package xmldatetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseResult(t *testing.T) {
+	for _, v := range []struct {
+		in     string
+		want   time.Time
+		zone   ZoneForm
+		digits int
+		kind   Kind
+	}{
+		{"2017-08-16T11:07:00", time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC), ZoneNone, 0, KindDateTime},
+		{"2017-08-16T11:07:00.500Z", time.Date(2017, time.August, 16, 11, 7, 0, 5e8, time.UTC), ZoneUTC, 3, KindDateTimeStamp},
+		{"2017-08-16T13:07:00.09251+02:00", time.Date(2017, time.August, 16, 11, 7, 0, 92510000, time.UTC), ZoneOffset, 5, KindDateTimeStamp},
+	} {
+		r, err := ParseResult(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		if !r.Time().Equal(v.want) {
+			t.Errorf("%s: want: %s, got: %s", v.in, v.want, r.Time())
+		}
+		if r.ZoneForm() != v.zone {
+			t.Errorf("%s: want zone form: %d, got: %d", v.in, v.zone, r.ZoneForm())
+		}
+		if r.FractionDigits() != v.digits {
+			t.Errorf("%s: want digits: %d, got: %d", v.in, v.digits, r.FractionDigits())
+		}
+		if r.Kind() != v.kind {
+			t.Errorf("%s: want kind: %s, got: %s", v.in, v.kind, r.Kind())
+		}
+	}
+	if _, err := ParseResult("2017-08-16"); err == nil {
+		t.Errorf("want error, got nil")
+	}
+}
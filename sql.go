@@ -0,0 +1,194 @@
+package xmldatetime
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Scan implements sql.Scanner. It accepts time.Time, a dateTime string or
+// []byte, and nil which leaves the zero value. Numeric sources are
+// rejected; see Decoder.Scanner and WithEpochScan.
+func (c *CustomTime) Scan(src interface{}) error {
+	return defaultDecoder.scan(c, src)
+}
+
+// Value implements driver.Valuer, emitting the dateTime lexical form. The
+// unset zero value, as scanned from NULL, is emitted as NULL.
+func (c CustomTime) Value() (driver.Value, error) {
+	if c.IsZero() {
+		return nil, nil
+	}
+	return format(c.Time, c.zone, nil), nil
+}
+
+// Scanner returns a sql.Scanner which scans into dst using the Decoder
+// options.
+func (d *Decoder) Scanner(dst *CustomTime) sql.Scanner {
+	return &scanner{d: d, dst: dst}
+}
+
+type scanner struct {
+	d   *Decoder
+	dst *CustomTime
+}
+
+func (s *scanner) Scan(src interface{}) error {
+	return s.d.scan(s.dst, src)
+}
+
+func (d *Decoder) scan(c *CustomTime, src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = CustomTime{}
+		return nil
+	case time.Time:
+		*c = CustomTime{Time: v}
+		return nil
+	case string:
+		return d.scanString(c, v)
+	case []byte:
+		return d.scanString(c, string(v))
+	}
+	if d.epochUnit <= 0 {
+		return fmt.Errorf("cannot scan %T into CustomTime", src)
+	}
+	var t time.Time
+	var err error
+	switch v := src.(type) {
+	case int64:
+		t, err = d.epochInt(v)
+	case float64:
+		t, err = d.epochFloat(v)
+	case json.Number:
+		if i, ierr := v.Int64(); ierr == nil {
+			t, err = d.epochInt(i)
+			break
+		}
+		var f float64
+		if f, err = v.Float64(); err == nil {
+			t, err = d.epochFloat(f)
+		}
+	default:
+		return fmt.Errorf("cannot scan %T into CustomTime", src)
+	}
+	if err != nil {
+		return err
+	}
+	*c = CustomTime{Time: t, zone: ZoneUTC}
+	return nil
+}
+
+func (d *Decoder) scanString(c *CustomTime, s string) error {
+	f, err := d.parse(s)
+	if err != nil {
+		return err
+	}
+	*c = CustomTime{Time: f.time(), zone: f.zone}
+	return nil
+}
+
+// epochInt returns the instant v units after the Unix epoch. The unit is
+// split into whole seconds and nanoseconds so that any unit is exact.
+func (d *Decoder) epochInt(v int64) (time.Time, error) {
+	unitSec, unitNsec := int64(d.epochUnit/time.Second), int64(d.epochUnit%time.Second)
+	// v*unitNsec nanoseconds, as whole seconds of q and the rest of r
+	q, r := v/1e9, v%1e9
+	sec, ok := mulInt64(v, unitSec)
+	frac, ok2 := mulInt64(q, unitNsec)
+	if !ok || !ok2 {
+		return not, errEpochRange
+	}
+	sec, ok = addInt64(sec, frac)
+	if !ok {
+		return not, errEpochRange
+	}
+	sec, ok = addInt64(sec, r*unitNsec/1e9)
+	if !ok {
+		return not, errEpochRange
+	}
+	return time.Unix(sec, r*unitNsec%1e9).UTC(), nil
+}
+
+var errEpochRange = errors.New("epoch value out of range")
+
+// mulInt64 returns a*b, and false when it overflows. b must not be negative.
+func mulInt64(a, b int64) (int64, bool) {
+	if b == 0 {
+		return 0, true
+	}
+	c := a * b
+	return c, c/b == a
+}
+
+// addInt64 returns a+b, and false when it overflows.
+func addInt64(a, b int64) (int64, bool) {
+	c := a + b
+	return c, (c > a) == (b > 0)
+}
+
+func (d *Decoder) epochFloat(v float64) (time.Time, error) {
+	secs := v * d.epochUnit.Seconds()
+	sec := math.Floor(secs)
+	if !(sec >= math.MinInt64 && sec < math.MaxInt64) {
+		return not, errEpochRange
+	}
+	return time.Unix(int64(sec), int64(math.Round((secs-sec)*1e9))).UTC(), nil
+}
+
+// Scan implements sql.Scanner for DATE columns. A time.Time keeps only its
+// date, as midnight in its location; a string or []byte is parsed as an
+// xs:date and nil leaves the zero value.
+func (d *DateOnly) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = DateOnly{}
+	case time.Time:
+		y, m, day := v.Date()
+		*d = DateOnly{Time: time.Date(y, m, day, 0, 0, 0, 0, v.Location())}
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into DateOnly", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer, emitting yyyy-mm-dd without timezone as
+// DATE columns expect. The zero value, as scanned from NULL, is emitted as
+// NULL.
+func (d DateOnly) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	y, m, day := d.Date()
+	return string(appendDate(make([]byte, 0, 16), y, int(m), day)), nil
+}
+
+// Scan implements sql.Scanner. It accepts an xs:duration string or []byte,
+// and nil which leaves the zero duration.
+func (d *Duration) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*d = Duration{}
+	case string:
+		*d, err = ParseDuration(v)
+	case []byte:
+		*d, err = ParseDuration(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into Duration", src)
+	}
+	return err
+}
+
+// Value implements driver.Valuer, emitting the canonical form of d.
+func (d Duration) Value() (driver.Value, error) {
+	return d.String(), nil
+}
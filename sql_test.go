@@ -0,0 +1,213 @@
+package xmldatetime
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCustomTime_Scan(t *testing.T) {
+	ex := time.Date(2017, time.August, 16, 11, 07, 0, 92510000, time.UTC)
+	for _, src := range []interface{}{
+		ex,
+		"2017-08-16T13:07:00.09251+02:00",
+		[]byte("2017-08-16T11:07:00.09251Z"),
+	} {
+		var c CustomTime
+		if err := c.Scan(src); err != nil {
+			t.Errorf("scan %T: %s", src, err)
+			continue
+		}
+		if !c.Time.Equal(ex) {
+			t.Errorf("scan %T: want: %s, got: %s", src, ex, c.Time)
+		}
+	}
+
+	c := CustomTime{Time: ex}
+	if err := c.Scan(nil); err != nil || !c.IsZero() {
+		t.Errorf("scan nil: want zero value, got: %s, %v", c.Time, err)
+	}
+	for _, src := range []interface{}{int64(1502881620), 1502881620.0, json.Number("1502881620")} {
+		if err := c.Scan(src); err == nil {
+			t.Errorf("scan %T: want error, got nil", src)
+		}
+	}
+}
+
+func TestDecoder_ScannerEpoch(t *testing.T) {
+	ex := time.Date(2017, time.August, 16, 11, 07, 0, 0, time.UTC)
+	var c CustomTime
+	s := NewDecoder(WithEpochScan(time.Second)).Scanner(&c)
+	for _, src := range []interface{}{int64(1502881620), 1502881620.0, json.Number("1502881620")} {
+		c = CustomTime{}
+		if err := s.Scan(src); err != nil {
+			t.Errorf("scan %T: %s", src, err)
+			continue
+		}
+		if !c.Time.Equal(ex) {
+			t.Errorf("scan %T: want: %s, got: %s", src, ex, c.Time)
+		}
+	}
+
+	ex = ex.Add(250 * time.Millisecond)
+	s = NewDecoder(WithEpochScan(time.Millisecond)).Scanner(&c)
+	for _, src := range []interface{}{int64(1502881620250), 1502881620250.0, json.Number("1502881620250")} {
+		c = CustomTime{}
+		if err := s.Scan(src); err != nil {
+			t.Errorf("scan %T: %s", src, err)
+			continue
+		}
+		if !c.Time.Equal(ex) {
+			t.Errorf("scan %T: want: %s, got: %s", src, ex, c.Time)
+		}
+	}
+}
+
+func TestDecoder_ScannerEpochUnit(t *testing.T) {
+	epoch := time.Unix(0, 0).UTC()
+	for _, v := range []struct {
+		unit time.Duration
+		src  int64
+	}{
+		{7 * time.Millisecond, 1502881620},
+		{3 * time.Microsecond, -1502881620},
+		{1500 * time.Millisecond, 1502881620},
+		{time.Hour, 417467},
+		{time.Nanosecond, 1502881620250000001},
+	} {
+		var c CustomTime
+		if err := NewDecoder(WithEpochScan(v.unit)).Scanner(&c).Scan(v.src); err != nil {
+			t.Errorf("%s %d: %s", v.unit, v.src, err)
+			continue
+		}
+		if want := epoch.Add(time.Duration(v.src) * v.unit); !c.Time.Equal(want) {
+			t.Errorf("%s %d: want: %s, got: %s", v.unit, v.src, want, c.Time)
+		}
+	}
+	var c CustomTime
+	for _, src := range []interface{}{int64(math.MaxInt64), int64(math.MinInt64), 1e300, math.NaN()} {
+		if err := NewDecoder(WithEpochScan(time.Hour)).Scanner(&c).Scan(src); err == nil {
+			t.Errorf("%v: want range error, got: %s", src, c.Time)
+		}
+	}
+}
+
+func TestDecoder_ScannerEpochResetsZone(t *testing.T) {
+	var c CustomTime
+	s := NewDecoder(WithEpochScan(time.Second)).Scanner(&c)
+	if err := s.Scan("2017-08-16T13:07:00"); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if err := s.Scan(int64(1502881620)); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if c.IsZoneless() {
+		t.Errorf("epoch value reported as zoneless")
+	}
+	if v, err := c.Value(); err != nil || v != "2017-08-16T11:07:00Z" {
+		t.Errorf("want: 2017-08-16T11:07:00Z, got: %v, %v", v, err)
+	}
+}
+
+func TestCustomTime_Value(t *testing.T) {
+	c := CustomTime{Time: time.Date(2017, time.August, 16, 13, 07, 0, 0, time.FixedZone("", -2*60*60))}
+	v, err := c.Value()
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if v != "2017-08-16T13:07:00-02:00" {
+		t.Errorf("want: 2017-08-16T13:07:00-02:00, got: %v", v)
+	}
+
+	if err := c.Scan(nil); err != nil {
+		t.Errorf("scan nil: %s", err)
+	}
+	if v, err := c.Value(); v != nil || err != nil {
+		t.Errorf("want NULL, got: %v, %v", v, err)
+	}
+	if err := c.Scan("0001-01-01T00:00:00Z"); err != nil {
+		t.Errorf("error: %s", err)
+	}
+	if v, err := c.Value(); v != "0001-01-01T00:00:00Z" || err != nil {
+		t.Errorf("want: 0001-01-01T00:00:00Z, got: %v, %v", v, err)
+	}
+}
+
+func TestDateOnly_Scan(t *testing.T) {
+	for _, v := range []struct {
+		src  interface{}
+		want string
+	}{
+		{time.Date(2017, time.August, 16, 13, 7, 0, 92510000, time.UTC), "2017-08-16"},
+		{time.Date(2017, time.August, 16, 23, 59, 59, 0, time.FixedZone("", 2*60*60)), "2017-08-16"},
+		{"2017-08-16", "2017-08-16"},
+		{[]byte(" 2017-08-16+02:00 "), "2017-08-16"},
+	} {
+		var d DateOnly
+		if err := d.Scan(v.src); err != nil {
+			t.Errorf("%v: %s", v.src, err)
+			continue
+		}
+		if h, m, s := d.Clock(); h != 0 || m != 0 || s != 0 || d.Nanosecond() != 0 {
+			t.Errorf("%v: want time discarded, got: %s", v.src, d.Time)
+		}
+		got, err := d.Value()
+		if err != nil || got != v.want {
+			t.Errorf("%v: want: %s, got: %v, %v", v.src, v.want, got, err)
+		}
+	}
+	d := DateOnly{Time: time.Now()}
+	if err := d.Scan(nil); err != nil || !d.IsZero() {
+		t.Errorf("nil: want zero value, got: %s, %v", d.Time, err)
+	}
+	if v, err := d.Value(); v != nil || err != nil {
+		t.Errorf("nil: want NULL back, got: %v, %v", v, err)
+	}
+	for _, src := range []interface{}{int64(1), "2017-08-16T13:07:00Z", "2017-02-30"} {
+		if err := d.Scan(src); err == nil {
+			t.Errorf("%v: want error, got nil", src)
+		}
+	}
+}
+
+func TestDuration_Scan(t *testing.T) {
+	for _, v := range []struct {
+		src  interface{}
+		want Duration
+	}{
+		{"P1Y2M3DT4H5M6.5S", Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 5e8}},
+		{[]byte("-PT90M"), Duration{Negative: true, Minutes: 90}},
+		{nil, Duration{}},
+	} {
+		d := Duration{Days: 9}
+		if err := d.Scan(v.src); err != nil {
+			t.Errorf("%v: %s", v.src, err)
+			continue
+		}
+		if d != v.want {
+			t.Errorf("%v: want: %+v, got: %+v", v.src, v.want, d)
+		}
+	}
+	var d Duration
+	for _, src := range []interface{}{"P1D1Y", int64(5), time.Second} {
+		if err := d.Scan(src); err == nil {
+			t.Errorf("%v: want error, got nil", src)
+		}
+	}
+	for _, v := range []struct {
+		d    Duration
+		want string
+	}{
+		{Duration{Years: 1, Hours: 2}, "P1YT2H"},
+		{Duration{Negative: true, Minutes: 90}, "-PT90M"},
+		{Duration{}, "PT0S"},
+	} {
+		if got, err := v.d.Value(); err != nil || got != v.want {
+			t.Errorf("want: %s, got: %v, %v", v.want, got, err)
+		}
+	}
+}
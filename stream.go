@@ -0,0 +1,269 @@
+package xmldatetime
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// CanonicalizeXML copies the tokens of dec to enc, rewriting the values of
+// the elements and attributes for which isTimestamp returns true into their
+// canonical representation (see CanonicalString). Everything else is passed
+// through unchanged, including namespace prefixes and declarations, as
+// signed documents require. isTimestamp gets names with the namespace URL
+// as Space, as from dec.Token. Empty timestamp elements are left empty.
+func CanonicalizeXML(dec *xml.Decoder, enc *xml.Encoder, isTimestamp func(xml.Name) bool) error {
+	var ns nsScopes
+	for {
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			return enc.Flush()
+		}
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			ns.push(tok.Attr)
+			if err := canonicalizeElement(dec, enc, tok, &ns, isTimestamp); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			ns.pop()
+			if err := enc.EncodeToken(xml.EndElement{Name: rawName(tok.Name)}); err != nil {
+				return err
+			}
+		default:
+			if err := enc.EncodeToken(xml.CopyToken(tok)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// canonicalizeElement writes the start of the element start and, for a
+// timestamp element, its canonical value and its end.
+func canonicalizeElement(dec *xml.Decoder, enc *xml.Encoder, start xml.StartElement, ns *nsScopes, isTimestamp func(xml.Name) bool) error {
+	out := xml.StartElement{Name: rawName(start.Name), Attr: make([]xml.Attr, len(start.Attr))}
+	for i, a := range start.Attr {
+		out.Attr[i] = xml.Attr{Name: rawName(a.Name), Value: a.Value}
+		if isNamespaceDecl(a.Name) || !isTimestamp(ns.resolve(a.Name, false)) {
+			continue
+		}
+		v, err := CanonicalString(collapse(a.Value))
+		if err != nil {
+			return fmt.Errorf("attribute %s: %w", a.Name.Local, err)
+		}
+		out.Attr[i].Value = v
+	}
+	if err := enc.EncodeToken(out); err != nil {
+		return err
+	}
+	if !isTimestamp(ns.resolve(start.Name, true)) {
+		return nil
+	}
+	v, err := elementText(dec)
+	if err != nil {
+		return err
+	}
+	ns.pop()
+	if v = collapse(v); v != "" {
+		if v, err = CanonicalString(v); err != nil {
+			return fmt.Errorf("element %s: %w", start.Name.Local, err)
+		}
+		if err := enc.EncodeToken(xml.CharData(v)); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(out.End())
+}
+
+// rawName returns the name n of a raw token as prefix:local in Local, so
+// that xml.Encoder writes it as is.
+func rawName(n xml.Name) xml.Name {
+	if n.Space == "" {
+		return n
+	}
+	return xml.Name{Local: n.Space + ":" + n.Local}
+}
+
+func isNamespaceDecl(n xml.Name) bool {
+	return n.Space == "xmlns" || n.Space == "" && n.Local == "xmlns"
+}
+
+// nsScopes holds the namespace declarations of the open elements, to
+// resolve the prefixes of raw tokens.
+type nsScopes []map[string]string
+
+func (s *nsScopes) push(attrs []xml.Attr) {
+	var m map[string]string
+	for _, a := range attrs {
+		if !isNamespaceDecl(a.Name) {
+			continue
+		}
+		if m == nil {
+			m = make(map[string]string)
+		}
+		if a.Name.Space == "" {
+			m[""] = a.Value
+		} else {
+			m[a.Name.Local] = a.Value
+		}
+	}
+	*s = append(*s, m)
+}
+
+func (s *nsScopes) pop() {
+	if len(*s) > 0 {
+		*s = (*s)[:len(*s)-1]
+	}
+}
+
+// resolve returns n with its prefix replaced by the namespace URL. The
+// default namespace applies to elements only.
+func (s nsScopes) resolve(n xml.Name, elem bool) xml.Name {
+	if n.Space == "" && !elem {
+		return n
+	}
+	if n.Space == "xml" {
+		return xml.Name{Space: "http://www.w3.org/XML/1998/namespace", Local: n.Local}
+	}
+	for i := len(s) - 1; i >= 0; i-- {
+		if url, ok := s[i][n.Space]; ok {
+			return xml.Name{Space: url, Local: n.Local}
+		}
+	}
+	return xml.Name{Space: n.Space, Local: n.Local}
+}
+
+// elementText reads the raw character data up to the end of the current
+// element, which must not contain elements.
+func elementText(dec *xml.Decoder) (string, error) {
+	b, err := appendText(nil, dec.RawToken)
+	return string(b), err
+}
+
+// ParseAll parses every element and attribute of the XML document data
+// whose path matches fn, and returns all values parsed and all errors met
+// instead of stopping at the first. Paths are made of local names, e.g.
+// /order/created for an element and /order/@updated for an attribute.
+// Empty elements hold no value and are skipped. A malformed document ends
+// the walk with its error appended.
+func ParseAll(data []byte, fn func(path string) bool) ([]time.Time, []error) {
+	var (
+		values []time.Time
+		errs   []error
+		path   []string
+		text   [][]byte
+	)
+	add := func(p, v string) {
+		t, err := Parse(collapse(v))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p, err))
+			return
+		}
+		values = append(values, t)
+	}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return values, errs
+		}
+		if err != nil {
+			return values, append(errs, err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			p := "/" + tok.Name.Local
+			if len(path) > 0 {
+				p = path[len(path)-1] + p
+			}
+			path = append(path, p)
+			text = append(text, nil)
+			for _, a := range tok.Attr {
+				if ap := p + "/@" + a.Name.Local; fn(ap) {
+					add(ap, a.Value)
+				}
+			}
+		case xml.CharData:
+			if len(text) > 0 {
+				text[len(text)-1] = append(text[len(text)-1], tok...)
+			}
+		case xml.EndElement:
+			p, v := path[len(path)-1], string(text[len(text)-1])
+			path, text = path[:len(path)-1], text[:len(text)-1]
+			if fn(p) && collapse(v) != "" {
+				add(p, v)
+			}
+		}
+	}
+}
+
+// DecodeInto reads the consecutive elements named elemName up to the end
+// of the enclosing element, or of the document, and appends their values
+// to dst. An empty space in elemName matches any namespace. Whitespace and
+// comments between the elements are skipped, any other content is an
+// error. Empty and nil elements append a zero value, as UnmarshalXML does.
+func DecodeInto(dec *xml.Decoder, dst *[]CustomTime, elemName xml.Name) error {
+	var buf [64]byte
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			return nil
+		case xml.CharData:
+			if len(bytes.Trim(tok, " \t\r\n")) != 0 {
+				return fmt.Errorf("unexpected character data %q", tok)
+			}
+		case xml.StartElement:
+			if tok.Name.Local != elemName.Local || elemName.Space != "" && tok.Name.Space != elemName.Space {
+				return fmt.Errorf("unexpected element %s", tok.Name.Local)
+			}
+			var c CustomTime
+			if isNil(tok) {
+				if err := dec.Skip(); err != nil {
+					return err
+				}
+				*dst = append(*dst, c)
+				continue
+			}
+			v, err := appendText(buf[:0], dec.Token)
+			if err != nil {
+				return err
+			}
+			if err := c.unmarshal(v); err != nil {
+				return fmt.Errorf("element %s: %w", tok.Name.Local, err)
+			}
+			*dst = append(*dst, c)
+		}
+	}
+}
+
+// appendText appends the character data up to the end of the current
+// element to b, which must not contain elements. next is dec.Token or
+// dec.RawToken.
+func appendText(b []byte, next func() (xml.Token, error)) ([]byte, error) {
+	for {
+		tok, err := next()
+		if err != nil {
+			return b, err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			b = append(b, tok...)
+		case xml.StartElement:
+			return b, fmt.Errorf("unexpected element %s in timestamp", tok.Name.Local)
+		case xml.EndElement:
+			return b, nil
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package xmldatetime
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCanonicalizeXML(t *testing.T) {
+	in := `<doc><created at="2017-08-16T13:07:00.50+02:00">2017-08-16T13:07:00.500+02:00</created>` +
+		`<note>2017-08-16T13:07:00.500+02:00</note>` +
+		`<updated> 2017-08-16T11:07:00+00:00 </updated><updated/></doc>`
+	want := `<doc><created at="2017-08-16T11:07:00.5Z">2017-08-16T11:07:00.5Z</created>` +
+		`<note>2017-08-16T13:07:00.500+02:00</note>` +
+		`<updated>2017-08-16T11:07:00Z</updated><updated></updated></doc>`
+	isTimestamp := func(n xml.Name) bool {
+		return n.Local == "created" || n.Local == "updated" || n.Local == "at"
+	}
+	var out bytes.Buffer
+	err := CanonicalizeXML(xml.NewDecoder(strings.NewReader(in)), xml.NewEncoder(&out), isTimestamp)
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if out.String() != want {
+		t.Errorf("want: %s, got: %s", want, out.String())
+	}
+
+	in = `<o:order xmlns:o="urn:o" xmlns="urn:d" o:at="2017-08-16T13:07:00.50+02:00">` +
+		`<o:created>2017-08-16T13:07:00.500+02:00</o:created><created>2017-08-16T13:07:00+00:00</created>` +
+		`<o:note>2017-08-16T13:07:00.500+02:00</o:note></o:order>`
+	want = `<o:order xmlns:o="urn:o" xmlns="urn:d" o:at="2017-08-16T11:07:00.5Z">` +
+		`<o:created>2017-08-16T11:07:00.5Z</o:created><created>2017-08-16T13:07:00+00:00</created>` +
+		`<o:note>2017-08-16T13:07:00.500+02:00</o:note></o:order>`
+	isOrderTimestamp := func(n xml.Name) bool {
+		return n.Space == "urn:o" && (n.Local == "created" || n.Local == "at")
+	}
+	out.Reset()
+	err = CanonicalizeXML(xml.NewDecoder(strings.NewReader(in)), xml.NewEncoder(&out), isOrderTimestamp)
+	if err != nil {
+		t.Errorf("namespaced: %s", err)
+	} else if out.String() != want {
+		t.Errorf("namespaced: want: %s, got: %s", want, out.String())
+	}
+
+	in = `<doc><created>yesterday</created></doc>`
+	err = CanonicalizeXML(xml.NewDecoder(strings.NewReader(in)), xml.NewEncoder(&out), isTimestamp)
+	if err == nil {
+		t.Errorf("want error, got nil")
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	in := `<order updated="2017-08-16T15:00:00Z">
+		<created>2017-08-16T13:07:00+02:00</created>
+		<shipped>2017-08-16 14:00:00Z</shipped>
+		<note>2017-13-01T00:00:00Z</note>
+		<shipped/>
+	</order>`
+	fn := func(p string) bool {
+		return p == "/order/created" || p == "/order/shipped" || p == "/order/@updated"
+	}
+	values, errs := ParseAll([]byte(in), fn)
+	want := []time.Time{
+		time.Date(2017, time.August, 16, 15, 0, 0, 0, time.UTC),
+		time.Date(2017, time.August, 16, 11, 7, 0, 0, time.UTC),
+	}
+	if len(values) != len(want) {
+		t.Errorf("want %d values, got: %v", len(want), values)
+		t.FailNow()
+	}
+	for i := range want {
+		if !values[i].Equal(want[i]) {
+			t.Errorf("%d: want: %s, got: %s", i, want[i], values[i])
+		}
+	}
+	if len(errs) != 1 {
+		t.Errorf("want 1 error, got: %v", errs)
+		t.FailNow()
+	}
+	if !strings.HasPrefix(errs[0].Error(), "/order/shipped: ") {
+		t.Errorf("want error for /order/shipped, got: %s", errs[0])
+	}
+
+	values, errs = ParseAll([]byte(`<a><b>2017-08-16T13:07:00Z</b><b>x</b>`), func(p string) bool { return p == "/a/b" })
+	if len(values) != 1 || len(errs) != 2 {
+		t.Errorf("want 1 value and 2 errors, got: %v, %v", values, errs)
+	}
+}
+
+func TestDecodeInto(t *testing.T) {
+	in := `<list>
+		<t>2017-08-16T13:07:00Z</t>
+		<t>2017-08-16T13:07:01.5+02:00</t>
+		<!-- gap -->
+		<t> 2017-08-16T13:07:02 </t>
+		<t/>
+		<t>2017-08-16T13:07:04-05:30</t>
+	</list><after/>`
+	dec := xml.NewDecoder(strings.NewReader(in))
+	if _, err := dec.Token(); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	dst := []CustomTime{{}}
+	if err := DecodeInto(dec, &dst, xml.Name{Local: "t"}); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	want := []string{"", "2017-08-16T13:07:00Z", "2017-08-16T13:07:01.5+02:00", "2017-08-16T13:07:02", "", "2017-08-16T13:07:04-05:30"}
+	if len(dst) != len(want) {
+		t.Errorf("want %d values, got: %d", len(want), len(dst))
+		t.FailNow()
+	}
+	for i, w := range want {
+		got, _ := dst[i].Value()
+		if w == "" {
+			if !dst[i].IsZero() {
+				t.Errorf("%d: want zero value, got: %v", i, got)
+			}
+			continue
+		}
+		if got != w {
+			t.Errorf("%d: want: %s, got: %v", i, w, got)
+		}
+	}
+	if tok, err := dec.Token(); err != nil || tok.(xml.StartElement).Name.Local != "after" {
+		t.Errorf("want decoder after </list>, got: %v, %v", tok, err)
+	}
+
+	for _, in := range []string{
+		"<list><t>2017-08-16T13:07:00Z</t><u/></list>",
+		"<list><t>2017-08-16 13:07:00Z</t></list>",
+		"<list>text<t>2017-08-16T13:07:00Z</t></list>",
+	} {
+		dec := xml.NewDecoder(strings.NewReader(in))
+		dec.Token()
+		var dst []CustomTime
+		if err := DecodeInto(dec, &dst, xml.Name{Local: "t"}); err == nil {
+			t.Errorf("%s: want error, got nil", in)
+		}
+	}
+}
+
+func BenchmarkDecodeInto(b *testing.B) {
+	in := strings.Repeat("<t>2017-08-16T13:07:00.09251+02:00</t>", 100)
+	dst := make([]CustomTime, 0, 100)
+	for i := 0; i < b.N; i++ {
+		dst = dst[:0]
+		if err := DecodeInto(xml.NewDecoder(strings.NewReader(in)), &dst, xml.Name{Local: "t"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package xmldatetime
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// maxCachedOffset bounds the zone cache to the offsets xs:dateTime allows,
+// in minutes.
+const maxCachedOffset = 14 * 60
+
+// zoneCache holds one location per whole-minute offset within ±14:00, so
+// it never exceeds 2*14*60+1 entries whatever the input.
+var zoneCache [2*maxCachedOffset + 1]atomic.Pointer[time.Location]
+
+// fixedZone returns the location of a fixed offset in seconds east of UTC,
+// time.UTC for zero.
+func fixedZone(offset int) *time.Location {
+	if offset == 0 {
+		return time.UTC
+	}
+	if offset%60 != 0 || offset < -maxCachedOffset*60 || offset > maxCachedOffset*60 {
+		return time.FixedZone(offsetName(offset), offset)
+	}
+	slot := &zoneCache[offset/60+maxCachedOffset]
+	if loc := slot.Load(); loc != nil {
+		return loc
+	}
+	loc := time.FixedZone(offsetName(offset), offset)
+	if !slot.CompareAndSwap(nil, loc) {
+		return slot.Load()
+	}
+	return loc
+}
+
+// cachedZones returns the number of locations in the zone cache.
+func cachedZones() int {
+	n := 0
+	for i := range zoneCache {
+		if zoneCache[i].Load() != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func offsetForm(offset int) ZoneForm {
+	if offset == 0 {
+		return ZoneUTC
+	}
+	return ZoneOffset
+}
+
+// SafeLocation returns the location of c, which is never nil. It is the
+// same as Location: time.Time stores a nil location for UTC and Location
+// already returns time.UTC for it, so a zero value or a time.Time built
+// without a location needs no guard. It only documents that guarantee.
+func (c CustomTime) SafeLocation() *time.Location {
+	return c.Location()
+}
+
+// mustBeOffset panics unless offset, in seconds, is a whole number of
+// minutes within ±14:00. Offsets are usually constants, so one out of
+// range is a programming error.
+func mustBeOffset(offset int) {
+	if offset%60 != 0 || offset < -14*60*60 || offset > 14*60*60 {
+		panic("xmldatetime: offset " + strconv.Itoa(offset) + "s is not whole minutes within ±14:00")
+	}
+}
+
+// InZone returns the same instant as c seen at the fixed offset of
+// offsetSeconds east of UTC. The wall clock changes, the instant does not.
+// It panics unless the offset is whole minutes within ±14:00.
+func (c CustomTime) InZone(offsetSeconds int) CustomTime {
+	mustBeOffset(offsetSeconds)
+	return CustomTime{Time: c.In(fixedZone(offsetSeconds)), zone: offsetForm(offsetSeconds)}
+}
+
+// RelabelOffset keeps the wall clock of c and replaces its zone with the
+// fixed offset of offsetSeconds east of UTC, so the instant moves by the
+// difference of the offsets. It corrects values written with a wrong
+// timezone, whereas InZone only changes the presentation of the instant.
+// It panics unless the offset is whole minutes within ±14:00.
+func (c CustomTime) RelabelOffset(offsetSeconds int) CustomTime {
+	mustBeOffset(offsetSeconds)
+	t := time.Date(c.Year(), c.Month(), c.Day(), c.Hour(), c.Minute(), c.Second(), c.Nanosecond(), fixedZone(offsetSeconds))
+	return CustomTime{Time: t, zone: offsetForm(offsetSeconds)}
+}
+
+// InNamedZone builds the given wall clock in the IANA zone zoneName and
+// keeps the offset the zone has at that instant as a fixed zone, which is
+// what a dateTime can carry.
+func InNamedZone(year, month, day, hour, min, sec, nsec int, zoneName string) (CustomTime, error) {
+	loc, err := time.LoadLocation(zoneName)
+	if err != nil {
+		return CustomTime{}, err
+	}
+	t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, loc)
+	_, offset := t.Zone()
+	return CustomTime{Time: t.In(fixedZone(offset)), zone: offsetForm(offset), zoneName: zoneName}, nil
+}
+
+// ZoneName returns the IANA zone name c was built in by InNamedZone, or ""
+// when c only has an offset, e.g. after parsing.
+func (c CustomTime) ZoneName() string {
+	return c.zoneName
+}
+
+// ValidateOffsetForZone reports whether the offset of t is the one the IANA
+// zone zoneName has at the instant of t.
+func ValidateOffsetForZone(t time.Time, zoneName string) (bool, error) {
+	loc, err := time.LoadLocation(zoneName)
+	if err != nil {
+		return false, err
+	}
+	_, want := t.In(loc).Zone()
+	_, got := t.Zone()
+	return got == want, nil
+}
@@ -0,0 +1,212 @@
+package xmldatetime
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCustomTime_RelabelOffset(t *testing.T) {
+	var c CustomTime
+	if err := c.Scan("2017-08-16T13:07:00Z"); err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	r := c.RelabelOffset(2 * 60 * 60)
+	if h, m, s := r.Clock(); h != 13 || m != 7 || s != 0 {
+		t.Errorf("want wall clock 13:07:00, got: %s", r.Time)
+	}
+	if d := c.Time.Sub(r.Time); d != 2*time.Hour {
+		t.Errorf("want instant shifted by 2h, got: %s", d)
+	}
+	if got := r.OffsetString(); got != "+02:00" {
+		t.Errorf("want: +02:00, got: %s", got)
+	}
+
+	z := c.InZone(2 * 60 * 60)
+	if !z.Time.Equal(c.Time) || z.Hour() != 15 {
+		t.Errorf("InZone: want same instant at 15:07, got: %s", z.Time)
+	}
+	if got := c.RelabelOffset(0).OffsetString(); got != "Z" {
+		t.Errorf("want: Z, got: %s", got)
+	}
+}
+
+func TestInNamedZone(t *testing.T) {
+	for _, v := range []struct {
+		month int
+		want  string
+	}{
+		{7, "2017-07-01T12:00:00+02:00"},
+		{1, "2017-01-01T12:00:00+01:00"},
+	} {
+		c, err := InNamedZone(2017, v.month, 1, 12, 0, 0, 0, "Europe/Warsaw")
+		if err != nil {
+			t.Skipf("no zoneinfo: %s", err)
+		}
+		if got := Format(c.Time); got != v.want {
+			t.Errorf("want: %s, got: %s", v.want, got)
+		}
+	}
+	if _, err := InNamedZone(2017, 7, 1, 12, 0, 0, 0, "Europe/Nowhere"); err == nil {
+		t.Errorf("want error, got nil")
+	}
+}
+
+func TestCustomTime_ZoneName(t *testing.T) {
+	c, err := InNamedZone(2017, 8, 16, 13, 7, 0, 0, "Europe/Warsaw")
+	if err != nil {
+		t.Skipf("no zoneinfo: %s", err)
+	}
+	if got := c.ZoneName(); got != "Europe/Warsaw" {
+		t.Errorf("want: Europe/Warsaw, got: %s", got)
+	}
+	got, err := xml.Marshal(&c)
+	if err != nil {
+		t.Errorf("marshaling: %s", err)
+		t.FailNow()
+	}
+	if want := "<CustomTime>2017-08-16T13:07:00+02:00</CustomTime>"; string(got) != want {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+	if got := c.InZone(0).ZoneName(); got != "" {
+		t.Errorf("InZone: want no zone name, got: %s", got)
+	}
+	r := c
+	if err := xml.Unmarshal([]byte("<t>2017-01-01T00:00:00-05:00</t>"), &r); err != nil {
+		t.Errorf("unmarshaling: %s", err)
+	}
+	if got := r.ZoneName(); got != "" {
+		t.Errorf("unmarshal: want no zone name, got: %s", got)
+	}
+	r = c
+	if err := r.UnmarshalXMLAttr(xml.Attr{Value: "2017-01-01T00:00:00-05:00"}); err != nil || r.ZoneName() != "" {
+		t.Errorf("unmarshal attr: want no zone name, got: %s, %v", r.ZoneName(), err)
+	}
+	p, err := Parse("2017-08-16T13:07:00+02:00")
+	if err != nil {
+		t.Errorf("error: %s", err)
+		t.FailNow()
+	}
+	if got := (CustomTime{Time: p}).ZoneName(); got != "" {
+		t.Errorf("want no zone name, got: %s", got)
+	}
+}
+
+func TestValidateOffsetForZone(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		want bool
+	}{
+		{"2017-07-01T12:00:00+01:00", false},
+		{"2017-07-01T12:00:00+02:00", true},
+		{"2017-01-01T12:00:00+01:00", true},
+		{"2017-01-01T12:00:00Z", false},
+	} {
+		tm, err := Parse(v.in)
+		if err != nil {
+			t.Errorf("%s: %s", v.in, err)
+			continue
+		}
+		ok, err := ValidateOffsetForZone(tm, "Europe/Warsaw")
+		if err != nil {
+			t.Skipf("no zoneinfo: %s", err)
+		}
+		if ok != v.want {
+			t.Errorf("%s: want: %v, got: %v", v.in, v.want, ok)
+		}
+	}
+	if _, err := ValidateOffsetForZone(time.Now(), "Europe/Nowhere"); err == nil {
+		t.Errorf("want error, got nil")
+	}
+}
+
+func TestFixedZone_Cache(t *testing.T) {
+	for m := -20 * 60; m <= 20*60; m++ {
+		sign, abs := "+", m
+		if m < 0 {
+			sign, abs = "-", -m
+		}
+		Parse(fmt.Sprintf("2017-08-16T13:07:00%s%02d:%02d", sign, abs/60, abs%60))
+	}
+	// every whole-minute offset but UTC is cached, nothing more
+	if n := cachedZones(); n != 2*maxCachedOffset {
+		t.Errorf("cache holds %d zones, want %d", n, 2*maxCachedOffset)
+	}
+	for _, v := range []string{"+14:01", "-15:00", "+13:60", "+1:00", "+99:99"} {
+		if _, err := Parse("2017-08-16T13:07:00" + v); err == nil {
+			t.Errorf("want error, got nil: %s", v)
+		}
+	}
+	if n := cachedZones(); n != 2*maxCachedOffset {
+		t.Errorf("invalid offsets changed the cache to %d zones", n)
+	}
+	for i := range zoneCache {
+		loc := zoneCache[i].Load()
+		if loc == nil {
+			continue
+		}
+		if _, off := time.Date(2017, 1, 1, 0, 0, 0, 0, loc).Zone(); off != (i-maxCachedOffset)*60 {
+			t.Errorf("slot %d holds offset %d", i, off)
+		}
+	}
+
+	// offsets the cache cannot hold are built on demand
+	odd := fixedZone(90*60*60 + 30)
+	if _, off := time.Date(2017, 1, 1, 0, 0, 0, 0, odd).Zone(); off != 90*60*60+30 {
+		t.Errorf("want offset %d, got: %d", 90*60*60+30, off)
+	}
+	if n := cachedZones(); n != 2*maxCachedOffset {
+		t.Errorf("cache grew to %d zones", n)
+	}
+	if fixedZone(2*60*60) != fixedZone(2*60*60) {
+		t.Errorf("want cached location to be reused")
+	}
+}
+
+func BenchmarkParseOffsetParallel(b *testing.B) {
+	offsets := []string{"+02:00", "-05:30", "+14:00", "-09:45", "+05:45", "Z"}
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if _, err := Parse("2017-08-16T13:07:00" + offsets[i%len(offsets)]); err != nil {
+				b.Fatal(err)
+			}
+			i++
+		}
+	})
+}
+
+func TestCustomTime_SafeLocation(t *testing.T) {
+	const in = "2017-08-16T13:07:00+02:00"
+	var parsers = []struct {
+		name  string
+		parse func(string) (time.Time, error)
+	}{
+		{"Parse", Parse},
+		{"ParseBytes", func(s string) (time.Time, error) { return ParseBytes([]byte(s)) }},
+		{"ParseIn", func(s string) (time.Time, error) { return ParseIn(s, nil) }},
+		{"ParseDateTimeStamp", ParseDateTimeStamp},
+		{"ParseRe", ParseRe},
+		{"ParseRe2", ParseRe2},
+		{"Lenient", NewLenient().Parse},
+		{"ParseDate", func(string) (time.Time, error) { return ParseDate("2017-08-16+02:00") }},
+		{"ParseTime", func(string) (time.Time, error) { return ParseTime("13:07:00") }},
+		{"Zoneless", func(string) (time.Time, error) { return Parse("2017-08-16T13:07:00") }},
+	}
+	for _, p := range parsers {
+		tm, err := p.parse(in)
+		if err != nil {
+			t.Errorf("%s: %s", p.name, err)
+			continue
+		}
+		c := CustomTime{Time: tm}
+		if c.SafeLocation() == nil || c.SafeLocation() != tm.Location() {
+			t.Errorf("%s: want location %s, got: %v", p.name, tm.Location(), c.SafeLocation())
+		}
+	}
+	if loc := (CustomTime{}).SafeLocation(); loc != time.UTC {
+		t.Errorf("zero value: want UTC, got: %v", loc)
+	}
+}